@@ -22,6 +22,7 @@ import (
 	"crypto/rand"
 	"crypto/sha512"
 	"crypto/subtle"
+	"encoding/binary"
 	"errors"
 	"hash"
 	"io"
@@ -61,25 +62,39 @@ type Signature struct {
 	R, S [sizeFr]byte
 }
 
-var one = new(big.Int).SetInt64(1)
-
-// randFieldElement returns a random element of the order of the given
-// curve using the procedure given in FIPS 186-4, Appendix B.5.1.
-func randFieldElement(rand io.Reader) (k *big.Int, err error) {
-	b := make([]byte, fr.Bits/8+8)
-	_, err = io.ReadFull(rand, b)
-	if err != nil {
-		return
+// randFieldElement returns a random non-zero element of fr, reducing 64 more
+// bits than the size of the order (so that the bias is negligible, as in FIPS
+// 186-4, Appendix B.5.1) with constant time field operations.
+func randFieldElement(rand io.Reader) (k fr.Element, err error) {
+	b := make([]byte, sizeFr+8)
+	for {
+		if _, err = io.ReadFull(rand, b); err != nil {
+			return
+		}
+		k = reduceBytes(b)
+		if !k.IsZero() {
+			return
+		}
 	}
+}
 
-	k = new(big.Int).SetBytes(b)
-	n := new(big.Int).Sub(order, one)
-	k.Mod(k, n)
-	k.Add(k, one)
-	return
+// reduceBytes returns the big-endian integer b modulo the order, len(b) being a
+// multiple of 8. Unlike fr.Element.SetBytes, it only uses constant time field
+// operations: b is read 64 bits at a time, k ← k⋅2⁶⁴ + bᵢ.
+func reduceBytes(b []byte) fr.Element {
+	var k, limb, twoTo64 fr.Element
+	twoTo64.SetUint64(1 << 32).Square(&twoTo64)
+	for i := 0; i < len(b); i += 8 {
+		limb.SetUint64(binary.BigEndian.Uint64(b[i : i+8]))
+		k.Mul(&k, &twoTo64).Add(&k, &limb)
+	}
+	return k
 }
 
 // GenerateKey generates a public and private key pair.
+//
+// The secret scalar is sampled with constant time field operations, but the
+// scalar multiplication computing the public key is not constant time.
 func GenerateKey(rand io.Reader) (*PrivateKey, error) {
 
 	k, err := randFieldElement(rand)
@@ -90,8 +105,10 @@ func GenerateKey(rand io.Reader) (*PrivateKey, error) {
 	_, _, g, _ := bls12377.Generators()
 
 	privateKey := new(PrivateKey)
-	k.FillBytes(privateKey.scalar[:sizeFr])
-	privateKey.PublicKey.A.ScalarMultiplication(&g, k)
+	privateKey.scalar = k.Bytes()
+	var kInt big.Int
+	k.BigInt(&kInt)
+	privateKey.PublicKey.A.ScalarMultiplication(&g, &kInt)
 	return privateKey, nil
 }
 
@@ -195,8 +212,30 @@ func (privKey *PrivateKey) Public() signature.PublicKey {
 //
 // SEC 1, Version 2.0, Section 4.1.3
 func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
-	scalar, r, s, kInv := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
-	scalar.SetBytes(privKey.scalar[:sizeFr])
+	// the secret scalar and the nonce are handled as fr elements, using
+	// constant time reduction and inversion. Note that the scalar
+	// multiplication k ⋅ g1Gen is not constant time.
+	var scalar, r, s, m, kInv fr.Element
+	scalar = reduceBytes(privKey.scalar[:sizeFr])
+
+	var mInt *big.Int
+	if hFunc != nil {
+		// compute the hash of the message as an integer
+		dataToHash := make([]byte, len(message))
+		copy(dataToHash[:], message[:])
+		hFunc.Reset()
+		_, err := hFunc.Write(dataToHash[:])
+		if err != nil {
+			return nil, err
+		}
+		hramBin := hFunc.Sum(nil)
+		mInt = HashToInt(hramBin)
+	} else {
+		mInt = HashToInt(message)
+	}
+	m.SetBigInt(mInt)
+
+	rInt, kInt := new(big.Int), new(big.Int)
 	for {
 		for {
 			csprng, err := nonce(privKey, message)
@@ -209,44 +248,29 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 			}
 
 			var P bls12377.G1Affine
-			P.ScalarMultiplicationBase(k)
-			kInv.ModInverse(k, order)
+			P.ScalarMultiplicationBase(k.BigInt(kInt))
+			kInv.InverseCT(&k)
 
-			P.X.BigInt(r)
-			r.Mod(r, order)
-			if r.Sign() != 0 {
+			P.X.BigInt(rInt)
+			r.SetBigInt(rInt) // r = x_P (mod order)
+			if !r.IsZero() {
 				break
 			}
 		}
-		s.Mul(r, scalar)
-
-		var m *big.Int
-		if hFunc != nil {
-			// compute the hash of the message as an integer
-			dataToHash := make([]byte, len(message))
-			copy(dataToHash[:], message[:])
-			hFunc.Reset()
-			_, err := hFunc.Write(dataToHash[:])
-			if err != nil {
-				return nil, err
-			}
-			hramBin := hFunc.Sum(nil)
-			m = HashToInt(hramBin)
-		} else {
-			m = HashToInt(message)
-		}
 
-		s.Add(m, s).
-			Mul(kInv, s).
-			Mod(s, order) // order != 0
-		if s.Sign() != 0 {
+		// s = k⁻¹ ⋅ (m + sk ⋅ r)
+		s.Mul(&r, &scalar).
+			Add(&s, &m).
+			Mul(&s, &kInv)
+		if !s.IsZero() {
 			break
 		}
 	}
 
 	var sig Signature
-	r.FillBytes(sig.R[:sizeFr])
-	s.FillBytes(sig.S[:sizeFr])
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	copy(sig.R[:], rBytes[:])
+	copy(sig.S[:], sBytes[:])
 
 	return sig.Bytes(), nil
 }
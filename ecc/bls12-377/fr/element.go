@@ -24,8 +24,10 @@ import (
 	"math/big"
 	"math/bits"
 	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/consensys/gnark-crypto/field/hash"
 	"github.com/consensys/gnark-crypto/field/pool"
@@ -307,6 +309,15 @@ func (z *Element) LexicographicallyLargest() bool {
 // This might error only if reading from crypto/rand.Reader errors,
 // in which case, value of z is undefined.
 func (z *Element) SetRandom() (*Element, error) {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value in [0, q), reading randomness from r.
+// Candidates larger than q are rejected, so the result is unbiased if r is.
+//
+// This might error only if reading from r errors,
+// in which case, value of z is undefined.
+func (z *Element) SetRandomFrom(r io.Reader) (*Element, error) {
 	// this code is generated for all modulus
 	// and derived from go/src/crypto/rand/util.go
 
@@ -329,7 +340,7 @@ func (z *Element) SetRandom() (*Element, error) {
 
 	for {
 		// note that bytes[k:l] is always 0
-		if _, err := io.ReadFull(rand.Reader, bytes[:k]); err != nil {
+		if _, err := io.ReadFull(r, bytes[:k]); err != nil {
 			return nil, err
 		}
 
@@ -685,19 +696,122 @@ func _reduceGeneric(z *Element) {
 }
 
 // BatchInvert returns a new slice with every element inverted.
-// Uses Montgomery batch inversion trick
+// Uses Montgomery batch inversion trick; large inputs are split in chunks that are
+// processed concurrently.
+//
+// Zero elements are mapped to zero, see BatchInvertReportZeroes to detect them.
 func BatchInvert(a []Element) []Element {
 	res := make([]Element, len(a))
 	if len(a) == 0 {
 		return res
 	}
 
-	zeroes := make([]bool, len(a))
+	batchInvertParallel(len(a), batchInvertNbChunks(len(a)), func(_, start, end int) {
+		batchInvert(res[start:end], a[start:end])
+	})
+
+	return res
+}
+
+// BatchInvertReportZeroes returns a new slice with every element inverted, and the
+// indices (in increasing order) of the elements of a which are zero and therefore
+// not invertible. The corresponding entries of the returned slice are set to zero.
+func BatchInvertReportZeroes(a []Element) (res []Element, zeroes []int) {
+	res = make([]Element, len(a))
+	if len(a) == 0 {
+		return
+	}
+
+	nbChunks := batchInvertNbChunks(len(a))
+	chunkZeroes := make([][]int, nbChunks)
+	batchInvertParallel(len(a), nbChunks, func(chunk, start, end int) {
+		batchInvert(res[start:end], a[start:end])
+		for i := start; i < end; i++ {
+			if a[i].IsZero() {
+				chunkZeroes[chunk] = append(chunkZeroes[chunk], i)
+			}
+		}
+	})
+
+	for i := 0; i < nbChunks; i++ {
+		zeroes = append(zeroes, chunkZeroes[i]...)
+	}
+
+	return
+}
+
+// BatchInvertInPlace replaces every element of a by its inverse.
+// Zero elements are left unchanged.
+//
+// Unlike BatchInvert, it doesn't allocate a result slice; the Montgomery batch
+// inversion trick is applied on blocks of batchInvertBlockSize elements, and
+// needs only one extra element per block.
+func BatchInvertInPlace(a []Element) {
+	if len(a) == 0 {
+		return
+	}
+
+	batchInvertParallel(len(a), batchInvertNbChunks(len(a)), func(_, start, end int) {
+		batchInvertInPlace(a[start:end])
+	})
+}
+
+const (
+	// batchInvertMinChunkSize is the minimum number of elements processed by
+	// a single goroutine in the batch inversion functions, which costs one field inversion
+	batchInvertMinChunkSize = 1 << 10
+
+	// batchInvertBlockSize is the size of the scratch space used by BatchInvertInPlace
+	batchInvertBlockSize = 1 << 10
+)
+
+// batchInvertNbChunks returns the number of chunks an input of size n is split into
+func batchInvertNbChunks(n int) int {
+	nbChunks := runtime.NumCPU()
+	if maxChunks := n / batchInvertMinChunkSize; maxChunks < nbChunks {
+		nbChunks = maxChunks
+	}
+	if nbChunks < 1 {
+		nbChunks = 1
+	}
+	return nbChunks
+}
+
+// batchInvertParallel splits [0, n) in nbChunks contiguous chunks and calls work on each of them
+// in a separate goroutine
+func batchInvertParallel(n, nbChunks int, work func(chunk, start, end int)) {
+	if nbChunks == 1 {
+		work(0, 0, n)
+		return
+	}
+
+	chunkSize := n / nbChunks
+	extra := n % nbChunks
+
+	var wg sync.WaitGroup
+	wg.Add(nbChunks)
+	start := 0
+	for i := 0; i < nbChunks; i++ {
+		end := start + chunkSize
+		if i < extra {
+			end++
+		}
+		go func(chunk, start, end int) {
+			work(chunk, start, end)
+			wg.Done()
+		}(i, start, end)
+		start = end
+	}
+	wg.Wait()
+}
+
+// batchInvert sets res[i] = a[i]⁻¹ (or 0 if a[i] == 0), using a single field inversion.
+// res and a must have the same length and must not overlap.
+func batchInvert(res, a []Element) {
 	accumulator := One()
 
 	for i := 0; i < len(a); i++ {
 		if a[i].IsZero() {
-			zeroes[i] = true
 			continue
 		}
 		res[i] = accumulator
@@ -707,14 +821,66 @@ func BatchInvert(a []Element) []Element {
 	accumulator.Inverse(&accumulator)
 
 	for i := len(a) - 1; i >= 0; i-- {
-		if zeroes[i] {
+		if a[i].IsZero() {
 			continue
 		}
 		res[i].Mul(&res[i], &accumulator)
 		accumulator.Mul(&accumulator, &a[i])
 	}
+}
 
-	return res
+// batchInvertInPlace sets a[i] = a[i]⁻¹ (or 0 if a[i] == 0), using a single field inversion.
+func batchInvertInPlace(a []Element) {
+	nbBlocks := (len(a) + batchInvertBlockSize - 1) / batchInvertBlockSize
+
+	// compute the product of the non-zero elements of each block
+	blockProducts := make([]Element, nbBlocks)
+	for j := 0; j < nbBlocks; j++ {
+		start, end := j*batchInvertBlockSize, (j+1)*batchInvertBlockSize
+		if end > len(a) {
+			end = len(a)
+		}
+		blockProducts[j].SetOne()
+		for i := start; i < end; i++ {
+			if a[i].IsZero() {
+				continue
+			}
+			blockProducts[j].Mul(&blockProducts[j], &a[i])
+		}
+	}
+
+	// block products are never zero
+	blockInverses := make([]Element, nbBlocks)
+	batchInvert(blockInverses, blockProducts)
+
+	// apply the batch inversion trick on each block, starting from the inverse of its product
+	prefix := make([]Element, batchInvertBlockSize)
+	var tmp Element
+	for j := 0; j < nbBlocks; j++ {
+		start, end := j*batchInvertBlockSize, (j+1)*batchInvertBlockSize
+		if end > len(a) {
+			end = len(a)
+		}
+
+		accumulator := One()
+		for i := start; i < end; i++ {
+			if a[i].IsZero() {
+				continue
+			}
+			prefix[i-start] = accumulator
+			accumulator.Mul(&accumulator, &a[i])
+		}
+
+		accumulator = blockInverses[j]
+		for i := end - 1; i >= start; i-- {
+			if a[i].IsZero() {
+				continue
+			}
+			tmp = a[i]
+			a[i].Mul(&prefix[i-start], &accumulator)
+			accumulator.Mul(&accumulator, &tmp)
+		}
+	}
 }
 
 func _butterflyGeneric(a, b *Element) {
@@ -738,16 +904,23 @@ func (z *Element) BitLen() int {
 	return bits.Len64(z[0])
 }
 
-// Hash msg to count prime field elements.
+// Hash msg to count prime field elements, using expand_message_xmd with SHA-256.
 // https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-06#section-5.2
 func Hash(msg, dst []byte, count int) ([]Element, error) {
+	return HashWith(hash.ExpandMsgXmd, msg, dst, count)
+}
+
+// HashWith hashes msg to count prime field elements, using expand to produce the uniform bytes
+// (see hash.ExpandMsgXmdWith and hash.ExpandMsgXofWith).
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-5.2
+func HashWith(expand hash.ExpandMsg, msg, dst []byte, count int) ([]Element, error) {
 	// 128 bits of security
 	// L = ceil((ceil(log2(p)) + k) / 8), where k is the security parameter = 128
 	const Bytes = 1 + (Bits-1)/8
 	const L = 16 + Bytes
 
 	lenInBytes := count * L
-	pseudoRandomBytes, err := hash.ExpandMsgXmd(msg, dst, lenInBytes)
+	pseudoRandomBytes, err := expand(msg, dst, lenInBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -1562,6 +1735,112 @@ func updateFactorsDecompose(c int64) (int64, int64) {
 	return f, g
 }
 
+// _bInverseExponentElement is q-2, used in InverseCT
+var _bInverseExponentElement big.Int
+
+func init() {
+	_bInverseExponentElement.Sub(&_modulus, big.NewInt(2))
+}
+
+// InverseCT z = x⁻¹ (mod q) in constant time, using Fermat's little theorem z = x^(q-2)
+//
+// The sequence of operations does not depend on the value of x, so InverseCT
+// runs in constant time as long as Mul and Square do. It is much slower than
+// Inverse and should only be used on secret values.
+//
+// if x == 0, sets and returns z = x
+func (z *Element) InverseCT(x *Element) *Element {
+	// the exponent is public, branching on its bits doesn't leak x
+	return z.Exp(*x, &_bInverseExponentElement)
+}
+
+// ExpCT z = xᵏ (mod q) in constant time.
+//
+// The same sequence of operations is performed for any x and any k < 2^253,
+// using a square and multiply always algorithm with constant time selection.
+// If k is negative, x is inverted with InverseCT; the sign of k is not hidden.
+func (z *Element) ExpCT(x Element, k *big.Int) *Element {
+	e := k
+	if k.Sign() == -1 {
+		// if k < 0: xᵏ (mod q) == (x⁻¹)ᵏ (mod q)
+		x.InverseCT(&x)
+
+		e = pool.BigInt.Get()
+		defer pool.BigInt.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := e.BitLen()
+	if nbBits < 253 {
+		nbBits = 253
+	}
+
+	var res, t Element
+	res.SetOne()
+	for i := nbBits - 1; i >= 0; i-- {
+		res.Square(&res)
+		t.Mul(&res, &x)
+		res.Select(int(e.Bit(i)), &res, &t)
+	}
+
+	return z.Set(&res)
+}
+
+// SqrtCT z = √x (mod q) in constant time.
+//
+// The sequence of operations does not depend on the value of x. Only whether x
+// is a square or not is revealed, by the return value: if the square root
+// doesn't exist (x is not a square mod q), SqrtCT leaves z unchanged and returns nil.
+func (z *Element) SqrtCT(x *Element) *Element {
+	// q ≡ 1 (mod 4)
+	// constant time Tonelli-Shanks, see RFC 9380, appendix I.4
+	// with q - 1 = 2ᵉ⋅s, s odd
+
+	var y, b, t, w, tt, yt, one Element
+	one.SetOne()
+
+	// w = x^((s-1)/2))
+	w.expBySqrtExp(*x)
+
+	// y = x^((s+1)/2)) = w * x
+	y.Mul(x, &w)
+
+	// t = xˢ = w * w * x = y * w
+	t.Mul(&w, &y)
+
+	// c = nonResidue ^ s
+	var c = Element{
+		4340692304772210610,
+		11102725085307959083,
+		15540458298643990566,
+		944526744080888988,
+	}
+
+	for k := 47; k > 1; k-- {
+		b = t
+		for j := 1; j < k-1; j++ {
+			b.Square(&b)
+		}
+		// ne = 0 if b == 1, 1 otherwise
+		d := b.NotEqual(&one)
+		ne := int((d | -d) >> 63)
+
+		yt.Mul(&y, &c)
+		y.Select(ne, &y, &yt)
+		c.Square(&c)
+		tt.Mul(&t, &c)
+		t.Select(ne, &t, &tt)
+	}
+
+	// ensure we found y such that y * y = x
+	var square Element
+	square.Square(&y)
+	if square.NotEqual(x) != 0 {
+		return nil
+	}
+	return z.Set(&y)
+}
+
 // negL negates in place [x | xHi] and return the new most significant word xHi
 func negL(x *Element, xHi uint64) uint64 {
 	var b uint64
@@ -21,9 +21,8 @@ import (
 	"runtime"
 
 	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark-crypto/internal/parallel"
-
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/utils"
 )
 
 // Decimation is used in the FFT call to select decimation in time or in frequency
@@ -41,47 +40,13 @@ const butterflyThreshold = 16
 // if decimation == DIT (decimation in time), the input must be in bit-reversed order
 // if decimation == DIF (decimation in frequency), the output will be in bit-reversed order
 // if coset if set, the FFT(a) returns the evaluation of a on a coset.
+// if the domain cardinality is not a power of 2 (see NewMixedRadixDomain), input and output are in natural order.
 func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset ...bool) {
-
-	numCPU := uint64(runtime.NumCPU())
-
 	_coset := false
 	if len(coset) > 0 {
 		_coset = coset[0]
 	}
-
-	// if coset != 0, scale by coset table
-	if _coset {
-		scale := func(cosetTable []fr.Element) {
-			parallel.Execute(len(a), func(start, end int) {
-				for i := start; i < end; i++ {
-					a[i].Mul(&a[i], &cosetTable[i])
-				}
-			})
-		}
-		if decimation == DIT {
-			scale(domain.CosetTableReversed)
-
-		} else {
-			scale(domain.CosetTable)
-		}
-	}
-
-	// find the stage where we should stop spawning go routines in our recursive calls
-	// (ie when we have as many go routines running as we have available CPUs)
-	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU))
-	if numCPU <= 1 {
-		maxSplits = -1
-	}
-
-	switch decimation {
-	case DIF:
-		difFFT(a, domain.Twiddles, 0, maxSplits, nil)
-	case DIT:
-		ditFFT(a, domain.Twiddles, 0, maxSplits, nil)
-	default:
-		panic("not implemented")
-	}
+	domain.fft(a, decimation, _coset, runtime.NumCPU())
 }
 
 // FFTInverse computes (recursively) the inverse discrete Fourier transform of a and stores the result in a
@@ -89,59 +54,146 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset ...bool)
 // if decimation == DIF (decimation in frequency), the output will be in bit-reversed order
 // coset sets the shift of the fft (0 = no shift, standard fft)
 // len(a) must be a power of 2, and w must be a len(a)th root of unity in field F.
+// if the domain cardinality is not a power of 2 (see NewMixedRadixDomain), input and output are in natural order.
 func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, coset ...bool) {
+	_coset := false
+	if len(coset) > 0 {
+		_coset = coset[0]
+	}
+	domain.fftInverse(a, decimation, _coset, runtime.NumCPU())
+}
 
-	numCPU := uint64(runtime.NumCPU())
+// FFTBatch computes the FFT of each polynomial in polys, as domain.FFT(polys[i], decimation, coset...) would.
+//
+// Rather than parallelizing each transform, it spreads the polynomials over the available CPUs,
+// each transform running on its own (or on a share of the CPUs if there are fewer polynomials than CPUs);
+// this avoids the per-call synchronization and keeps each polynomial in a single core's cache.
+func (domain *Domain) FFTBatch(polys [][]fr.Element, decimation Decimation, coset ...bool) {
+	_coset := false
+	if len(coset) > 0 {
+		_coset = coset[0]
+	}
+	batch(polys, func(a []fr.Element, numCPU int) {
+		domain.fft(a, decimation, _coset, numCPU)
+	})
+}
 
+// FFTInverseBatch computes the inverse FFT of each polynomial in polys,
+// as domain.FFTInverse(polys[i], decimation, coset...) would. See FFTBatch.
+func (domain *Domain) FFTInverseBatch(polys [][]fr.Element, decimation Decimation, coset ...bool) {
 	_coset := false
 	if len(coset) > 0 {
 		_coset = coset[0]
 	}
+	batch(polys, func(a []fr.Element, numCPU int) {
+		domain.fftInverse(a, decimation, _coset, numCPU)
+	})
+}
 
-	// find the stage where we should stop spawning go routines in our recursive calls
-	// (ie when we have as many go routines running as we have available CPUs)
-	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU))
+// batch runs f on each polynomial, sharing the CPUs among them
+func batch(polys [][]fr.Element, f func(a []fr.Element, numCPU int)) {
+	if len(polys) == 0 {
+		return
+	}
+	numCPU := runtime.NumCPU()
+	cpusPerPoly := numCPU / len(polys)
+	if cpusPerPoly < 1 {
+		cpusPerPoly = 1
+	}
+	nbTasks := numCPU
+	if nbTasks > len(polys) {
+		nbTasks = len(polys)
+	}
+	utils.Parallelize(len(polys), func(start, end int) {
+		for i := start; i < end; i++ {
+			f(polys[i], cpusPerPoly)
+		}
+	}, nbTasks)
+}
+
+// maxSplits returns the stage where we should stop spawning go routines in our recursive calls
+// (ie when we have as many go routines running as we have available CPUs)
+func maxSplits(numCPU int) int {
 	if numCPU <= 1 {
-		maxSplits = -1
+		return -1
 	}
-	switch decimation {
-	case DIF:
-		difFFT(a, domain.TwiddlesInv, 0, maxSplits, nil)
-	case DIT:
-		ditFFT(a, domain.TwiddlesInv, 0, maxSplits, nil)
-	default:
-		panic("not implemented")
+	return bits.TrailingZeros64(ecc.NextPowerOfTwo(uint64(numCPU)))
+}
+
+func (domain *Domain) fft(a []fr.Element, decimation Decimation, coset bool, numCPU int) {
+	// if coset != 0, scale by coset table
+	if coset {
+		// mixed-radix FFTs work in natural order, power of 2 DIT ones take a in bit-reversed order
+		reversed := decimation == DIT && domain.radices == nil
+		if cosetTable := domain.cosetTable(reversed); cosetTable != nil {
+			utils.Parallelize(len(a), func(start, end int) {
+				for i := start; i < end; i++ {
+					a[i].Mul(&a[i], &cosetTable[i])
+				}
+			}, numCPU)
+		} else {
+			scaleByPowers(a, fr.One(), domain.FrMultiplicativeGen, reversed, numCPU)
+		}
+	}
+
+	if domain.radices != nil {
+		domain.mixedRadixFFT(a, domain.twiddles(), numCPU)
+		return
+	}
+	radix2FFT(a, domain.twiddles(), decimation, !domain.withoutFourStep, numCPU)
+}
+
+func (domain *Domain) fftInverse(a []fr.Element, decimation Decimation, coset bool, numCPU int) {
+	if domain.radices != nil {
+		domain.mixedRadixFFT(a, domain.twiddlesInv(), numCPU)
+	} else {
+		radix2FFT(a, domain.twiddlesInv(), decimation, !domain.withoutFourStep, numCPU)
 	}
 
 	// scale by CardinalityInv
-	if !_coset {
-		parallel.Execute(len(a), func(start, end int) {
+	if !coset {
+		utils.Parallelize(len(a), func(start, end int) {
 			for i := start; i < end; i++ {
 				a[i].Mul(&a[i], &domain.CardinalityInv)
 			}
-		})
+		}, numCPU)
 		return
 	}
 
-	scale := func(cosetTable []fr.Element) {
-		parallel.Execute(len(a), func(start, end int) {
-			for i := start; i < end; i++ {
-				a[i].Mul(&a[i], &cosetTable[i]).
-					Mul(&a[i], &domain.CardinalityInv)
-			}
-		})
-	}
-	if decimation == DIT {
-		scale(domain.CosetTableInv)
+	// mixed-radix FFTs work in natural order, power of 2 DIF ones output a in bit-reversed order
+	reversed := decimation == DIF && domain.radices == nil
+	cosetTableInv := domain.cosetTableInv(reversed)
+	if cosetTableInv == nil {
+		scaleByPowers(a, domain.CardinalityInv, domain.FrMultiplicativeGenInv, reversed, numCPU)
 		return
 	}
+	utils.Parallelize(len(a), func(start, end int) {
+		for i := start; i < end; i++ {
+			a[i].Mul(&a[i], &cosetTableInv[i]).
+				Mul(&a[i], &domain.CardinalityInv)
+		}
+	}, numCPU)
+}
 
-	// decimation == DIF
-	scale(domain.CosetTableInvReversed)
-
+// radix2FFT computes the FFT of a, of size a power of 2, with the given twiddles: recursively, or with
+// the four-step algorithm if fourStep is set and a is large enough (see WithoutFourStep).
+// DIF takes a in natural order and outputs it in bit-reversed order, DIT does the opposite.
+func radix2FFT(a []fr.Element, twiddles [][]fr.Element, decimation Decimation, fourStep bool, numCPU int) {
+	if decimation != DIF && decimation != DIT {
+		panic("not implemented")
+	}
+	if !fourStep || len(a) < fourStepThreshold {
+		if decimation == DIF {
+			difFFT(a, twiddles, 0, maxSplits(numCPU), numCPU, nil)
+		} else {
+			ditFFT(a, twiddles, 0, maxSplits(numCPU), numCPU, nil)
+		}
+		return
+	}
+	fourStepFFT(a, twiddles, decimation, numCPU)
 }
 
-func difFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDone chan struct{}) {
+func difFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits, numCPU int, chDone chan struct{}) {
 	if chDone != nil {
 		defer close(chDone)
 	}
@@ -156,16 +208,15 @@ func difFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDon
 	m := n >> 1
 
 	// if stage < maxSplits, we parallelize this butterfly
-	// but we have only numCPU / stage cpus available
+	// but we have only numCPU / 2^stage cpus available
 	if (m > butterflyThreshold) && (stage < maxSplits) {
 		// 1 << stage == estimated used CPUs
-		numCPU := runtime.NumCPU() / (1 << (stage))
-		parallel.Execute(m, func(start, end int) {
+		utils.Parallelize(m, func(start, end int) {
 			for i := start; i < end; i++ {
 				fr.Butterfly(&a[i], &a[i+m])
 				a[i+m].Mul(&a[i+m], &twiddles[stage][i])
 			}
-		}, numCPU)
+		}, numCPU/(1<<stage))
 	} else {
 		// i == 0
 		fr.Butterfly(&a[0], &a[m])
@@ -182,17 +233,17 @@ func difFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDon
 	nextStage := stage + 1
 	if stage < maxSplits {
 		chDone := make(chan struct{}, 1)
-		go difFFT(a[m:n], twiddles, nextStage, maxSplits, chDone)
-		difFFT(a[0:m], twiddles, nextStage, maxSplits, nil)
+		go difFFT(a[m:n], twiddles, nextStage, maxSplits, numCPU, chDone)
+		difFFT(a[0:m], twiddles, nextStage, maxSplits, numCPU, nil)
 		<-chDone
 	} else {
-		difFFT(a[0:m], twiddles, nextStage, maxSplits, nil)
-		difFFT(a[m:n], twiddles, nextStage, maxSplits, nil)
+		difFFT(a[0:m], twiddles, nextStage, maxSplits, numCPU, nil)
+		difFFT(a[m:n], twiddles, nextStage, maxSplits, numCPU, nil)
 	}
 
 }
 
-func ditFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDone chan struct{}) {
+func ditFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits, numCPU int, chDone chan struct{}) {
 	if chDone != nil {
 		defer close(chDone)
 	}
@@ -210,26 +261,25 @@ func ditFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDon
 	if stage < maxSplits {
 		// that's the only time we fire go routines
 		chDone := make(chan struct{}, 1)
-		go ditFFT(a[m:], twiddles, nextStage, maxSplits, chDone)
-		ditFFT(a[0:m], twiddles, nextStage, maxSplits, nil)
+		go ditFFT(a[m:], twiddles, nextStage, maxSplits, numCPU, chDone)
+		ditFFT(a[0:m], twiddles, nextStage, maxSplits, numCPU, nil)
 		<-chDone
 	} else {
-		ditFFT(a[0:m], twiddles, nextStage, maxSplits, nil)
-		ditFFT(a[m:n], twiddles, nextStage, maxSplits, nil)
+		ditFFT(a[0:m], twiddles, nextStage, maxSplits, numCPU, nil)
+		ditFFT(a[m:n], twiddles, nextStage, maxSplits, numCPU, nil)
 
 	}
 
 	// if stage < maxSplits, we parallelize this butterfly
-	// but we have only numCPU / stage cpus available
+	// but we have only numCPU / 2^stage cpus available
 	if (m > butterflyThreshold) && (stage < maxSplits) {
 		// 1 << stage == estimated used CPUs
-		numCPU := runtime.NumCPU() / (1 << (stage))
-		parallel.Execute(m, func(start, end int) {
+		utils.Parallelize(m, func(start, end int) {
 			for k := start; k < end; k++ {
 				a[k+m].Mul(&a[k+m], &twiddles[stage][k])
 				fr.Butterfly(&a[k], &a[k+m])
 			}
-		}, numCPU)
+		}, numCPU/(1<<stage))
 
 	} else {
 		fr.Butterfly(&a[0], &a[m])
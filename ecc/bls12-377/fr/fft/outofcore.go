@@ -0,0 +1,253 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/utils"
+)
+
+// ReaderWriterAt is the storage the out-of-core FFTs write to; *os.File implements it.
+type ReaderWriterAt interface {
+	io.ReaderAt
+	io.WriterAt
+}
+
+// FFTOutOfCore computes the evaluations on the domain of the polynomial whose Cardinality coefficients
+// (in canonical basis) are stored in src, and writes them, in natural order, to dst.
+//
+// Elements are stored contiguously, on fr.Bytes bytes each, as encoded by fr.BigEndian.
+// dst is also used as scratch space, and must not overlap src.
+//
+// This is Bailey's four-step FFT: the polynomial is seen as a n₂ × n₁ matrix (n₁⋅n₂ = Cardinality),
+// on whose columns then rows FFTs of size √Cardinality are computed, a batch at a time. maxMemory bounds
+// (in bytes) the memory used for these batches; it must be large enough to hold at least one column,
+// that is about 2⋅fr.Bytes⋅√Cardinality bytes. Only power of 2 domains are supported.
+func (domain *Domain) FFTOutOfCore(dst ReaderWriterAt, src io.ReaderAt, maxMemory int) error {
+	return domain.fftOutOfCore(dst, src, maxMemory, false)
+}
+
+// FFTInverseOutOfCore computes the coefficients of the polynomial whose evaluations on the domain
+// (in natural order) are stored in src, and writes them to dst. See FFTOutOfCore for the storage
+// layout and the memory bound.
+func (domain *Domain) FFTInverseOutOfCore(dst ReaderWriterAt, src io.ReaderAt, maxMemory int) error {
+	return domain.fftOutOfCore(dst, src, maxMemory, true)
+}
+
+// fftOutOfCore writes X[k₂ + n₂⋅k₁] = Σᵢ₁ ω₁^(i₁k₁) ⋅ ω^(i₁k₂) ⋅ Σᵢ₂ ω₂^(i₂k₂) ⋅ a[i₁ + n₁⋅i₂]
+// to dst, with ω the domain generator (or its inverse), ω₁ = ω^n₂ and ω₂ = ω^n₁:
+//  1. the n₁ columns of a (of size n₂) are transformed, multiplied by the twiddles ω^(i₁k₂)
+//     and written as the rows of a n₁ × n₂ matrix in dst;
+//  2. the n₂ columns of this matrix (of size n₁) are transformed in place.
+func (domain *Domain) fftOutOfCore(dst ReaderWriterAt, src io.ReaderAt, maxMemory int, inverse bool) error {
+	if domain.radices != nil {
+		return errors.New("out-of-core FFT requires a power of 2 domain")
+	}
+	n := int(domain.Cardinality)
+	logN := bits.TrailingZeros(uint(n))
+	n1 := 1 << (logN / 2)
+	n2 := n / n1
+
+	// a column is held both decoded and encoded
+	const elementCost = 2 * fr.Bytes
+	if maxMemory < n2*elementCost {
+		return fmt.Errorf("out-of-core FFT of size %d needs at least %d bytes of memory", n, n2*elementCost)
+	}
+	batchSize := func(nbColumns, columnSize int) int {
+		if b := maxMemory / (columnSize * elementCost); b < nbColumns {
+			return b
+		}
+		return nbColumns
+	}
+
+	// only the forward or inverse twiddles of the sub-domains are needed
+	domain1 := NewDomain(uint64(n1), WithoutPrecompute(), WithoutCosetTables())
+	domain2 := NewDomain(uint64(n2), WithoutPrecompute(), WithoutCosetTables())
+	transform := func(domain *Domain, columns [][]fr.Element) {
+		if inverse {
+			domain.FFTInverseBatch(columns, DIF)
+		} else {
+			domain.FFTBatch(columns, DIF)
+		}
+		utils.Parallelize(len(columns), func(start, end int) {
+			for i := start; i < end; i++ {
+				BitReverse(columns[i])
+			}
+		})
+	}
+	omega := domain.Generator
+	if inverse {
+		omega = domain.GeneratorInv
+	}
+
+	// step 1: columns of src
+	b := batchSize(n1, n2)
+	columns, raw := allocateColumns(b, n2)
+	var shift fr.Element // ω^i₁
+	shift.SetOne()
+	for c := 0; c < n1; c += b {
+		batch := columns
+		if c+b > n1 {
+			batch = columns[:n1-c]
+		}
+		if err := readColumns(src, batch, raw, c, n1); err != nil {
+			return err
+		}
+		transform(domain2, batch)
+
+		shifts := make([]fr.Element, len(batch))
+		for j := range shifts {
+			shifts[j] = shift
+			shift.Mul(&shift, &omega)
+		}
+		utils.Parallelize(len(batch), func(start, end int) {
+			for j := start; j < end; j++ {
+				var twiddle fr.Element
+				twiddle.SetOne()
+				for k := range batch[j] {
+					batch[j][k].Mul(&batch[j][k], &twiddle)
+					twiddle.Mul(&twiddle, &shifts[j])
+				}
+			}
+		})
+
+		if err := writeRows(dst, batch, raw, c); err != nil {
+			return err
+		}
+	}
+
+	// step 2: columns of dst
+	b = batchSize(n2, n1)
+	columns, raw = allocateColumns(b, n1)
+	for c := 0; c < n2; c += b {
+		batch := columns
+		if c+b > n2 {
+			batch = columns[:n2-c]
+		}
+		if err := readColumns(dst, batch, raw, c, n2); err != nil {
+			return err
+		}
+		transform(domain1, batch)
+		if err := writeColumns(dst, batch, raw, c, n2); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// allocateColumns returns nbColumns columns of columnSize elements, and a buffer for their encoding
+func allocateColumns(nbColumns, columnSize int) ([][]fr.Element, []byte) {
+	elements := make([]fr.Element, nbColumns*columnSize)
+	columns := make([][]fr.Element, nbColumns)
+	for i := range columns {
+		columns[i] = elements[i*columnSize : (i+1)*columnSize]
+	}
+	return columns, make([]byte, nbColumns*columnSize*fr.Bytes)
+}
+
+// readColumns reads the columns [c, c+len(columns)) of the matrix with rows of rowSize elements stored in r
+func readColumns(r io.ReaderAt, columns [][]fr.Element, raw []byte, c, rowSize int) error {
+	const size = fr.Bytes
+	nbColumns, nbRows := len(columns), len(columns[0])
+	raw = raw[:nbColumns*nbRows*size]
+	for i := 0; i < nbRows; i++ {
+		chunk := raw[i*nbColumns*size : (i+1)*nbColumns*size]
+		if err := readAt(r, chunk, int64(i*rowSize+c)*size); err != nil {
+			return err
+		}
+	}
+
+	var (
+		errOnce   sync.Once
+		decodeErr error
+	)
+	utils.Parallelize(nbRows, func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := 0; j < nbColumns; j++ {
+				var err error
+				offset := (i*nbColumns + j) * size
+				columns[j][i], err = fr.BigEndian.Element((*[size]byte)(raw[offset : offset+size]))
+				if err != nil {
+					errOnce.Do(func() { decodeErr = err })
+					return
+				}
+			}
+		}
+	})
+	return decodeErr
+}
+
+// writeColumns writes the columns [c, c+len(columns)) of the matrix with rows of rowSize elements stored in w
+func writeColumns(w io.WriterAt, columns [][]fr.Element, raw []byte, c, rowSize int) error {
+	const size = fr.Bytes
+	nbColumns, nbRows := len(columns), len(columns[0])
+	raw = raw[:nbColumns*nbRows*size]
+	utils.Parallelize(nbRows, func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := 0; j < nbColumns; j++ {
+				offset := (i*nbColumns + j) * size
+				fr.BigEndian.PutElement((*[size]byte)(raw[offset:offset+size]), columns[j][i])
+			}
+		}
+	})
+
+	for i := 0; i < nbRows; i++ {
+		chunk := raw[i*nbColumns*size : (i+1)*nbColumns*size]
+		if _, err := w.WriteAt(chunk, int64(i*rowSize+c)*size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRows writes the rows [r, r+len(rows)) of the matrix with rows of len(rows[0]) elements stored in w
+func writeRows(w io.WriterAt, rows [][]fr.Element, raw []byte, r int) error {
+	const size = fr.Bytes
+	rowSize := len(rows[0])
+	raw = raw[:len(rows)*rowSize*size]
+	utils.Parallelize(len(rows), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range rows[i] {
+				offset := (i*rowSize + j) * size
+				fr.BigEndian.PutElement((*[size]byte)(raw[offset:offset+size]), rows[i][j])
+			}
+		}
+	})
+
+	_, err := w.WriteAt(raw, int64(r*rowSize)*size)
+	return err
+}
+
+// readAt fills buf with the bytes of r at offset off
+func readAt(r io.ReaderAt, buf []byte, off int64) error {
+	n, err := r.ReadAt(buf, off)
+	if n == len(buf) {
+		// a ReaderAt may return io.EOF along with a full read
+		return nil
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
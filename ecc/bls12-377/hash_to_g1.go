@@ -18,6 +18,7 @@ package bls12377
 
 import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark-crypto/field/hash"
 
 	"math/big"
 )
@@ -280,9 +281,15 @@ func MapToG1(u fp.Element) G1Affine {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func EncodeToG1(msg, dst []byte) (G1Affine, error) {
+	return EncodeToG1With(hash.ExpandMsgXmd, msg, dst)
+}
+
+// EncodeToG1With is EncodeToG1 with expand in place of expand_message_xmd with SHA-256,
+// to match suites built on other hash functions (see hash.ExpandMsgXmdWith and hash.ExpandMsgXofWith).
+func EncodeToG1With(expand hash.ExpandMsg, msg, dst []byte) (G1Affine, error) {
 
 	var res G1Affine
-	u, err := fp.Hash(msg, dst, 1)
+	u, err := fp.HashWith(expand, msg, dst, 1)
 	if err != nil {
 		return res, err
 	}
@@ -300,7 +307,13 @@ func EncodeToG1(msg, dst []byte) (G1Affine, error) {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func HashToG1(msg, dst []byte) (G1Affine, error) {
-	u, err := fp.Hash(msg, dst, 2*1)
+	return HashToG1With(hash.ExpandMsgXmd, msg, dst)
+}
+
+// HashToG1With is HashToG1 with expand in place of expand_message_xmd with SHA-256,
+// to match suites built on other hash functions (see hash.ExpandMsgXmdWith and hash.ExpandMsgXofWith).
+func HashToG1With(expand hash.ExpandMsg, msg, dst []byte) (G1Affine, error) {
+	u, err := fp.HashWith(expand, msg, dst, 2*1)
 	if err != nil {
 		return G1Affine{}, err
 	}
@@ -17,9 +17,13 @@
 package bls12377
 
 import (
+	"crypto"
+	_ "crypto/sha512"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark-crypto/field/hash"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/prop"
+	"golang.org/x/crypto/sha3"
 	"math/rand"
 	"testing"
 )
@@ -79,6 +83,50 @@ func TestHashToFpG1(t *testing.T) {
 	}
 }
 
+func TestHashToG1With(t *testing.T) {
+	dst := hashToG1Vector.dst
+	for _, c := range hashToG1Vector.cases {
+		// the default expander is expand_message_xmd with SHA-256
+		q, err := HashToG1With(hash.ExpandMsgXmd, []byte(c.msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g1TestMatchPoint(t, "P", c.msg, c.P, &q)
+
+		expanders := map[string]hash.ExpandMsg{
+			"xmd:SHA-512":  hash.ExpandMsgXmdWith(crypto.SHA512),
+			"xmd:SHA3-256": hash.ExpandMsgXmdWith(crypto.SHA3_256),
+			"xof:SHAKE128": hash.ExpandMsgXofWith(sha3.NewShake128),
+			"xof:SHAKE256": hash.ExpandMsgXofWith(sha3.NewShake256),
+		}
+		for name, expand := range expanders {
+			p, err := HashToG1With(expand, []byte(c.msg), dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !p.IsInSubGroup() {
+				t.Fatalf("%s: hash output not in subgroup", name)
+			}
+			if p.Equal(&q) {
+				t.Fatalf("%s: hash output matches the default expander's", name)
+			}
+
+			u, err := fp.HashWith(expand, []byte(c.msg), dst, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err = EncodeToG1With(expand, []byte(c.msg), dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := MapToG1(g1CoordAt(u, 0))
+			if !p.Equal(&expected) {
+				t.Fatalf("%s: encode output doesn't match the mapping of the hashed field element", name)
+			}
+		}
+	}
+}
+
 func TestMapToCurve1(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
@@ -130,3 +130,77 @@ func (vector Vector) Less(i, j int) bool {
 func (vector Vector) Swap(i, j int) {
 	vector[i], vector[j] = vector[j], vector[i]
 }
+
+// ScalarMul multiplies a vector by a scalar element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector Vector) ScalarMul(a Vector, b *Element) {
+	if len(a) != len(vector) {
+		panic("vector.ScalarMul: vectors don't have the same length")
+	}
+	for i := 0; i < len(a); i++ {
+		vector[i].Mul(&a[i], b)
+	}
+}
+
+// Mul multiplies two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector Vector) Mul(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(vector) {
+		panic("vector.Mul: vectors don't have the same length")
+	}
+	for i := 0; i < len(a); i++ {
+		vector[i].Mul(&a[i], &b[i])
+	}
+}
+
+// Sum computes the sum of all elements in the vector.
+func (vector Vector) Sum() (res Element) {
+	for i := 0; i < len(vector); i++ {
+		res.Add(&res, &vector[i])
+	}
+	return
+}
+
+// Random returns a vector of n elements sampled uniformly in [0, q), reading randomness from r
+// (see Element.SetRandomFrom).
+func Random(r io.Reader, n int) (Vector, error) {
+	vector := make(Vector, n)
+	for i := range vector {
+		if _, err := vector[i].SetRandomFrom(r); err != nil {
+			return nil, err
+		}
+	}
+	return vector, nil
+}
+
+// InnerProduct computes the inner product of two vectors.
+// It panics if the vectors don't have the same length.
+func (vector Vector) InnerProduct(other Vector) (res Element) {
+	if len(vector) != len(other) {
+		panic("vector.InnerProduct: vectors don't have the same length")
+	}
+	var tmp Element
+	for i := 0; i < len(vector); i++ {
+		tmp.Mul(&vector[i], &other[i])
+		res.Add(&res, &tmp)
+	}
+	return
+}
+
+func addVecGeneric(res, a, b Vector) {
+	if len(a) != len(b) || len(a) != len(res) {
+		panic("vector.Add: vectors don't have the same length")
+	}
+	for i := 0; i < len(a); i++ {
+		res[i].Add(&a[i], &b[i])
+	}
+}
+
+func subVecGeneric(res, a, b Vector) {
+	if len(a) != len(b) || len(a) != len(res) {
+		panic("vector.Sub: vectors don't have the same length")
+	}
+	for i := 0; i < len(a); i++ {
+		res[i].Sub(&a[i], &b[i])
+	}
+}
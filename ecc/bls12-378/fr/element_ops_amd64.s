@@ -228,3 +228,82 @@ TEXT ·Butterfly(SB), NOSPLIT, $0-16
 	MOVQ SI, 16(AX)
 	MOVQ DI, 24(AX)
 	RET
+
+// addVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] + b[0...n]
+TEXT ·addVec(SB), NOSPLIT, $0-32
+	MOVQ res+0(FP), CX
+	MOVQ a+8(FP), AX
+	MOVQ b+16(FP), DX
+	MOVQ n+24(FP), BX
+
+l1:
+	TESTQ BX, BX
+	JEQ   l2         // n == 0, we are done
+	MOVQ  0(AX), SI
+	MOVQ  8(AX), DI
+	MOVQ  16(AX), R8
+	MOVQ  24(AX), R9
+	ADDQ  0(DX), SI
+	ADCQ  8(DX), DI
+	ADCQ  16(DX), R8
+	ADCQ  24(DX), R9
+
+	// reduce element(SI,DI,R8,R9) using temp registers (R10,R11,R12,R13)
+	REDUCE(SI,DI,R8,R9,R10,R11,R12,R13)
+
+	MOVQ SI, 0(CX)
+	MOVQ DI, 8(CX)
+	MOVQ R8, 16(CX)
+	MOVQ R9, 24(CX)
+	ADDQ $32, AX
+	ADDQ $32, DX
+	ADDQ $32, CX
+	DECQ BX         // decrement n
+	JMP  l1
+
+l2:
+	RET
+
+// subVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] - b[0...n]
+TEXT ·subVec(SB), NOSPLIT, $0-32
+	MOVQ res+0(FP), CX
+	MOVQ a+8(FP), AX
+	MOVQ b+16(FP), DX
+	MOVQ n+24(FP), BX
+
+l3:
+	TESTQ   BX, BX
+	JEQ     l4                       // n == 0, we are done
+	XORQ    R10, R10
+	MOVQ    0(AX), SI
+	MOVQ    8(AX), DI
+	MOVQ    16(AX), R8
+	MOVQ    24(AX), R9
+	SUBQ    0(DX), SI
+	SBBQ    8(DX), DI
+	SBBQ    16(DX), R8
+	SBBQ    24(DX), R9
+	MOVQ    $0x3291440000000001, R11
+	MOVQ    $0xeae77f3da0940001, R12
+	MOVQ    $0x87787fb4e3dbb0ff, R13
+	MOVQ    $0x20e7b9c8ef7b2eb1, R14
+	CMOVQCC R10, R11
+	CMOVQCC R10, R12
+	CMOVQCC R10, R13
+	CMOVQCC R10, R14
+	ADDQ    R11, SI
+	ADCQ    R12, DI
+	ADCQ    R13, R8
+	ADCQ    R14, R9
+	MOVQ    SI, 0(CX)
+	MOVQ    DI, 8(CX)
+	MOVQ    R8, 16(CX)
+	MOVQ    R9, 24(CX)
+	ADDQ    $32, AX
+	ADDQ    $32, DX
+	ADDQ    $32, CX
+	DECQ    BX                       // decrement n
+	JMP     l3
+
+l4:
+	RET
@@ -0,0 +1,125 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/utils"
+)
+
+// FFTShifted computes the evaluations of the polynomial a on the coset shift⋅H, H being the domain
+// subgroup, and stores the result in a; decimation is as in FFT.
+//
+// domain.FFT(a, decimation, true) is domain.FFTShifted(a, decimation, &domain.FrMultiplicativeGen),
+// without the precomputed coset table.
+func (domain *Domain) FFTShifted(a []fr.Element, decimation Decimation, shift *fr.Element) {
+	scaleByPowers(a, fr.One(), *shift, decimation == DIT && domain.radices == nil, runtime.NumCPU())
+	domain.FFT(a, decimation)
+}
+
+// FFTInverseShifted computes the coefficients of the polynomial whose evaluations on the coset shift⋅H
+// are a, and stores the result in a; decimation is as in FFTInverse.
+func (domain *Domain) FFTInverseShifted(a []fr.Element, decimation Decimation, shift *fr.Element) {
+	var shiftInv fr.Element
+	shiftInv.Inverse(shift)
+	domain.FFTInverse(a, decimation)
+	scaleByPowers(a, fr.One(), shiftInv, decimation == DIF && domain.radices == nil, runtime.NumCPU())
+}
+
+// CosetShift returns the shift g⋅ωⁱ of the i-th coset of H in g⋅H', where H' ⊇ H is the subgroup of order
+// blowup⋅Cardinality, ω its generator (satisfying ω^blowup = Generator) and g = FrMultiplicativeGen.
+//
+// The cosets for i in [0, blowup) partition g⋅H'; evaluating on all of them is a low-degree extension (see LDE).
+func (domain *Domain) CosetShift(blowup, i int) fr.Element {
+	omega := domain.blownUpGenerator(blowup)
+	var shift fr.Element
+	shift.Exp(omega, big.NewInt(int64(i))).Mul(&shift, &domain.FrMultiplicativeGen)
+	return shift
+}
+
+// LDE returns the low-degree extension of poly (given by its len(poly) <= Cardinality coefficients in
+// canonical basis): its evaluations, in natural order, on g⋅H' where H' is the subgroup of order
+// blowup⋅Cardinality and g = FrMultiplicativeGen.
+//
+// res[i + blowup⋅j] is the evaluation at g⋅ωⁱ⋅Generatorʲ; that is, the blowup cosets of H (see CosetShift)
+// are interleaved. They are computed as FFTs of size Cardinality, reusing the domain twiddles.
+func (domain *Domain) LDE(poly []fr.Element, blowup int) []fr.Element {
+	n := int(domain.Cardinality)
+	if len(poly) > n {
+		panic(fmt.Sprintf("polynomial of size %d is larger than the domain (%d)", len(poly), n))
+	}
+	omega := domain.blownUpGenerator(blowup)
+
+	cosets := make([][]fr.Element, blowup)
+	shift := domain.FrMultiplicativeGen
+	for i := range cosets {
+		cosets[i] = make([]fr.Element, n)
+		copy(cosets[i], poly)
+		scaleByPowers(cosets[i][:len(poly)], fr.One(), shift, false, runtime.NumCPU())
+		shift.Mul(&shift, &omega)
+	}
+
+	domain.FFTBatch(cosets, DIF)
+
+	res := make([]fr.Element, blowup*n)
+	utils.Parallelize(blowup, func(start, end int) {
+		for i := start; i < end; i++ {
+			if domain.radices == nil {
+				BitReverse(cosets[i])
+			}
+			for j := 0; j < n; j++ {
+				res[i+blowup*j] = cosets[i][j]
+			}
+		}
+	})
+	return res
+}
+
+// blownUpGenerator returns ω of order blowup⋅Cardinality such that ω^blowup = Generator.
+// For power of 2 domains, blowup must be a power of 2.
+func (domain *Domain) blownUpGenerator(blowup int) fr.Element {
+	if blowup < 1 {
+		panic("blowup must be positive")
+	}
+	if domain.radices == nil {
+		if blowup&(blowup-1) != 0 {
+			panic("the blowup factor of a power of 2 domain must be a power of 2")
+		}
+		logOrder := uint64(bits.TrailingZeros64(domain.Cardinality)) + uint64(bits.TrailingZeros(uint(blowup)))
+		if logOrder > MaxOrderRoot {
+			panic(fmt.Sprintf("blowup %d is too big: the required root of unity does not exist", blowup))
+		}
+		return twoAdicRootOfUnity(logOrder)
+	}
+
+	// mixed-radix domains use Generator = FrMultiplicativeGen^((q-1)/Cardinality)
+	var order, e, mod big.Int
+	order.SetUint64(domain.Cardinality).Mul(&order, big.NewInt(int64(blowup)))
+	e.Sub(fr.Modulus(), big.NewInt(1))
+	if mod.Mod(&e, &order).Sign() != 0 {
+		panic(fmt.Sprintf("blowup %d is too big: the required root of unity does not exist", blowup))
+	}
+	e.Div(&e, &order)
+	var omega fr.Element
+	omega.Exp(domain.FrMultiplicativeGen, &e)
+	return omega
+}
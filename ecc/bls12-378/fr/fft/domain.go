@@ -17,6 +17,7 @@
 package fft
 
 import (
+	"encoding/binary"
 	"fmt"
 	"io"
 	"math/big"
@@ -26,12 +27,11 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
 
-	curve "github.com/consensys/gnark-crypto/ecc/bls12-378"
-
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/utils"
 )
 
-// Domain with a power of 2 cardinality
+// Domain with a power of 2 cardinality (see NewDomain), or a mixed-radix one (see NewMixedRadixDomain)
 // compute a field element of order 2x and store it in FinerGenerator
 // all other values can be derived from x, GeneratorSqrt
 type Domain struct {
@@ -42,9 +42,13 @@ type Domain struct {
 	FrMultiplicativeGen    fr.Element // generator of Fr*
 	FrMultiplicativeGenInv fr.Element
 
-	// the following slices are not serialized and are (re)computed through domain.preComputeTwiddles()
+	// the following slices are not serialized and are (re)computed through domain.preComputeTwiddles().
+	// They are nil if the domain was created WithoutPrecompute (the FFTs then compute the tables they
+	// need on first use, and keep them private to the domain); the coset tables are also nil if it
+	// was created WithoutCosetTables.
 
 	// Twiddles factor for the FFT using Generator for each stage of the recursive FFT
+	// (for mixed-radix domains, the stages follow the radices, see mixedRadixTwiddles)
 	Twiddles [][]fr.Element
 
 	// Twiddles factor for the FFT using GeneratorInv for each stage of the recursive FFT
@@ -59,100 +63,260 @@ type Domain struct {
 	// CosetTable[i][j] = domain.Generator(i-th)SqrtInv ^ j
 	CosetTableInv         []fr.Element
 	CosetTableInvReversed []fr.Element // optional, this is computed on demand at the creation of the domain
+
+	// radices of the mixed-radix FFT, in the order its stages are applied; nil if Cardinality is a power of 2.
+	// see NewMixedRadixDomain
+	radices []uint64
+
+	// options the domain was created with; they are serialized with it
+	withoutPrecompute  bool
+	withoutCosetTables bool
+	withoutFourStep    bool
+
+	// tables computed on first use; behind a pointer so that the domain can be copied
+	// (see getTables: it is nil if the domain was not created by NewDomain, NewMixedRadixDomain or ReadFrom)
+	tables *domainTables
+}
+
+// tablesLock guards the allocation of the tables of the domains which were not created with them
+var tablesLock sync.Mutex
+
+// domainTables holds the tables of a domain, see twiddles, twiddlesInv, cosetTable and cosetTableInv
+type domainTables struct {
+	twiddlesOnce, twiddlesInvOnce, cosetTableOnce, cosetTableInvOnce sync.Once
+
+	twiddles, twiddlesInv                [][]fr.Element
+	cosetTable, cosetTableReversed       []fr.Element
+	cosetTableInv, cosetTableInvReversed []fr.Element
+}
+
+// DomainOption configures which tables a domain precomputes, see NewDomain and NewMixedRadixDomain
+type DomainOption func(*Domain)
+
+// WithoutPrecompute makes the domain compute its twiddle factors (and coset tables) on first use
+// instead of at creation; forward and inverse tables are computed independently, so that a domain
+// only used for forward FFTs never holds the inverse ones.
+func WithoutPrecompute() DomainOption {
+	return func(d *Domain) {
+		d.withoutPrecompute = true
+	}
+}
+
+// WithoutCosetTables makes the domain never store its coset tables (CosetTable, CosetTableInv and their
+// bit-reversed versions, 4 times the size of the domain): FFTs on the coset compute the powers of the
+// multiplicative generator on the fly instead, at the cost of one more multiplication per element.
+func WithoutCosetTables() DomainOption {
+	return func(d *Domain) {
+		d.withoutCosetTables = true
+	}
+}
+
+// WithoutFourStep makes the power of 2 FFTs always use the recursive algorithm. By default, FFTs of at
+// least 2²² elements use the four-step algorithm, which works on rows of √n elements (and a scratch space
+// of n elements) and is faster once the domain no longer fits in cache.
+func WithoutFourStep() DomainOption {
+	return func(d *Domain) {
+		d.withoutFourStep = true
+	}
 }
 
 // NewDomain returns a subgroup with a power of 2 cardinality
 // cardinality >= m
-func NewDomain(m uint64) *Domain {
+//
+// By default, the twiddle factors and coset tables are precomputed; see DomainOption to trade this memory
+// for computations.
+func NewDomain(m uint64, opts ...DomainOption) *Domain {
 
 	domain := &Domain{}
+	for _, opt := range opts {
+		opt(domain)
+	}
 	x := ecc.NextPowerOfTwo(m)
 	domain.Cardinality = uint64(x)
 
-	// generator of the largest 2-adic subgroup
-	var rootOfUnity fr.Element
-
-	rootOfUnity.SetString("4045585818372166415418670827807793147093034396422209590578257013290761627990")
-	const maxOrderRoot uint64 = 42
 	domain.FrMultiplicativeGen.SetUint64(22)
 
 	domain.FrMultiplicativeGenInv.Inverse(&domain.FrMultiplicativeGen)
 
 	// find generator for Z/2^(log(m))Z
 	logx := uint64(bits.TrailingZeros64(x))
-	if logx > maxOrderRoot {
+	if logx > MaxOrderRoot {
 		panic(fmt.Sprintf("m (%d) is too big: the required root of unity does not exist", m))
 	}
 
 	// Generator = FinerGenerator^2 has order x
-	expo := uint64(1 << (maxOrderRoot - logx))
-	domain.Generator.Exp(rootOfUnity, big.NewInt(int64(expo))) // order x
+	domain.Generator = twoAdicRootOfUnity(logx) // order x
 	domain.GeneratorInv.Inverse(&domain.Generator)
 	domain.CardinalityInv.SetUint64(uint64(x)).Inverse(&domain.CardinalityInv)
 
-	// twiddle factors
+	// twiddle factors and coset tables
 	domain.preComputeTwiddles()
 
-	// store the bit reversed coset tables
-	domain.reverseCosetTables()
-
 	return domain
 }
 
-func (d *Domain) reverseCosetTables() {
-	d.CosetTableReversed = make([]fr.Element, d.Cardinality)
-	d.CosetTableInvReversed = make([]fr.Element, d.Cardinality)
-	copy(d.CosetTableReversed, d.CosetTable)
-	copy(d.CosetTableInvReversed, d.CosetTableInv)
-	BitReverse(d.CosetTableReversed)
-	BitReverse(d.CosetTableInvReversed)
+// MaxOrderRoot is the log₂ of the order of the largest 2-adic subgroup of the field, that is of the
+// cardinality of the largest domain NewDomain can build
+const MaxOrderRoot uint64 = 42
+
+// twoAdicRootOfUnity returns the generator of the subgroup of order 2^logOrder used by the power of 2 domains.
+// logOrder must not exceed MaxOrderRoot.
+func twoAdicRootOfUnity(logOrder uint64) fr.Element {
+	// generator of the largest 2-adic subgroup
+	var rootOfUnity fr.Element
+	rootOfUnity.SetString("4045585818372166415418670827807793147093034396422209590578257013290761627990")
+
+	expo := uint64(1 << (MaxOrderRoot - logOrder))
+	rootOfUnity.Exp(rootOfUnity, new(big.Int).SetUint64(expo))
+	return rootOfUnity
 }
 
+// preComputeTwiddles computes the twiddle factors and the coset tables (with their bit-reversed versions),
+// and exposes them in the fields of the domain, unless it was created WithoutPrecompute or WithoutCosetTables
 func (d *Domain) preComputeTwiddles() {
+	d.tables = new(domainTables)
+	if d.withoutPrecompute {
+		return
+	}
 
-	// nb fft stages
-	nbStages := uint64(bits.TrailingZeros64(d.Cardinality))
+	var wg sync.WaitGroup
+	run := func(f func()) {
+		wg.Add(1)
+		go func() {
+			f()
+			wg.Done()
+		}()
+	}
+	run(func() { d.Twiddles = d.twiddles() })
+	run(func() { d.TwiddlesInv = d.twiddlesInv() })
+	run(func() { d.cosetTable(false) })
+	run(func() { d.cosetTableInv(false) })
+	wg.Wait()
+	if !d.withoutCosetTables {
+		d.CosetTable, d.CosetTableReversed = d.tables.cosetTable, d.tables.cosetTableReversed
+		d.CosetTableInv, d.CosetTableInvReversed = d.tables.cosetTableInv, d.tables.cosetTableInvReversed
+	}
+}
 
-	d.Twiddles = make([][]fr.Element, nbStages)
-	d.TwiddlesInv = make([][]fr.Element, nbStages)
-	d.CosetTable = make([]fr.Element, d.Cardinality)
-	d.CosetTableInv = make([]fr.Element, d.Cardinality)
+// getTables returns the tables of the domain, allocating them if it was built field by field
+func (d *Domain) getTables() *domainTables {
+	tablesLock.Lock()
+	defer tablesLock.Unlock()
+	if d.tables == nil {
+		d.tables = new(domainTables)
+	}
+	return d.tables
+}
 
-	var wg sync.WaitGroup
+// twiddles returns the twiddle factors of the FFT, computing them if needed
+func (d *Domain) twiddles() [][]fr.Element {
+	t := d.getTables()
+	t.twiddlesOnce.Do(func() {
+		t.twiddles = d.computeTwiddles(d.Generator)
+	})
+	return t.twiddles
+}
 
-	// for each fft stage, we pre compute the twiddle factors
-	twiddles := func(t [][]fr.Element, omega fr.Element) {
-		for i := uint64(0); i < nbStages; i++ {
-			t[i] = make([]fr.Element, 1+(1<<(nbStages-i-1)))
-			var w fr.Element
-			if i == 0 {
-				w = omega
-			} else {
-				w = t[i-1][2]
-			}
-			t[i][0] = fr.One()
-			t[i][1] = w
-			for j := 2; j < len(t[i]); j++ {
-				t[i][j].Mul(&t[i][j-1], &w)
-			}
-		}
-		wg.Done()
+// twiddlesInv returns the twiddle factors of the inverse FFT, computing them if needed
+func (d *Domain) twiddlesInv() [][]fr.Element {
+	t := d.getTables()
+	t.twiddlesInvOnce.Do(func() {
+		t.twiddlesInv = d.computeTwiddles(d.GeneratorInv)
+	})
+	return t.twiddlesInv
+}
+
+// cosetTable returns the powers of FrMultiplicativeGen (bit-reversed if reversed is set and the
+// domain is a power of 2 one), computing them if needed; nil if the domain was created WithoutCosetTables
+func (d *Domain) cosetTable(reversed bool) []fr.Element {
+	if d.withoutCosetTables {
+		return nil
+	}
+	t := d.getTables()
+	t.cosetTableOnce.Do(func() {
+		t.cosetTable, t.cosetTableReversed = d.computeCosetTable(d.FrMultiplicativeGen)
+	})
+	if reversed && d.radices == nil {
+		return t.cosetTableReversed
 	}
+	return t.cosetTable
+}
 
-	expTable := func(sqrt fr.Element, t []fr.Element) {
-		t[0] = fr.One()
-		precomputeExpTable(sqrt, t)
-		wg.Done()
+// cosetTableInv is the cosetTable of FrMultiplicativeGenInv
+func (d *Domain) cosetTableInv(reversed bool) []fr.Element {
+	if d.withoutCosetTables {
+		return nil
+	}
+	t := d.getTables()
+	t.cosetTableInvOnce.Do(func() {
+		t.cosetTableInv, t.cosetTableInvReversed = d.computeCosetTable(d.FrMultiplicativeGenInv)
+	})
+	if reversed && d.radices == nil {
+		return t.cosetTableInvReversed
 	}
+	return t.cosetTableInv
+}
 
-	wg.Add(4)
-	go twiddles(d.Twiddles, d.Generator)
-	go twiddles(d.TwiddlesInv, d.GeneratorInv)
-	go expTable(d.FrMultiplicativeGen, d.CosetTable)
-	go expTable(d.FrMultiplicativeGenInv, d.CosetTableInv)
+// computeTwiddles returns the twiddle factors of the FFT with root of unity omega
+func (d *Domain) computeTwiddles(omega fr.Element) [][]fr.Element {
+	if d.radices != nil {
+		return d.mixedRadixTwiddles(omega)
+	}
 
-	wg.Wait()
+	// nb fft stages
+	nbStages := uint64(bits.TrailingZeros64(d.Cardinality))
 
+	// for each fft stage, we pre compute the twiddle factors
+	t := make([][]fr.Element, nbStages)
+	for i := uint64(0); i < nbStages; i++ {
+		t[i] = make([]fr.Element, 1+(1<<(nbStages-i-1)))
+		var w fr.Element
+		if i == 0 {
+			w = omega
+		} else {
+			w = t[i-1][2]
+		}
+		t[i][0] = fr.One()
+		t[i][1] = w
+		for j := 2; j < len(t[i]); j++ {
+			t[i][j].Mul(&t[i][j-1], &w)
+		}
+	}
+	return t
+}
+
+// computeCosetTable returns <1,w,..,w^(n-1)> and, for power of 2 domains, its bit-reversed version
+// (mixed-radix FFTs work in natural order)
+func (d *Domain) computeCosetTable(w fr.Element) (table, reversed []fr.Element) {
+	table = make([]fr.Element, d.Cardinality)
+	table[0] = fr.One()
+	precomputeExpTable(w, table)
+	if d.radices != nil {
+		return table, nil
+	}
+	reversed = make([]fr.Element, d.Cardinality)
+	copy(reversed, table)
+	BitReverse(reversed)
+	return table, reversed
+}
+
+// scaleByPowers multiplies a[i] by scale⋅wⁱ (or a[bitReverse(i)] if reversed is set) without
+// storing the powers of w
+func scaleByPowers(a []fr.Element, scale, w fr.Element, reversed bool, numCPU int) {
+	n := len(a)
+	shift := uint64(64 - bits.TrailingZeros64(uint64(n)))
+	utils.Parallelize(n, func(start, end int) {
+		var p fr.Element
+		p.Exp(w, big.NewInt(int64(start))).Mul(&p, &scale)
+		for i := start; i < end; i++ {
+			j := i
+			if reversed {
+				j = int(bits.Reverse64(uint64(i)) >> shift)
+			}
+			a[j].Mul(&a[j], &p)
+			p.Mul(&p, &w)
+		}
+	}, numCPU)
 }
 
 func precomputeExpTable(w fr.Element, table []fr.Element) {
@@ -200,41 +364,145 @@ func precomputeExpTableChunk(w fr.Element, power uint64, table []fr.Element) {
 	}
 }
 
+// A serialized domain is its cardinality, an options field and its field elements. The options
+// field starts with a marker which is not a canonical field element: readers predating it (which
+// expect CardinalityInv there) fail instead of misdecoding the domain, and the domains serialized
+// by them are still read, without options.
+//
+//	options field: marker (fr.Bytes bytes 0xFF) | version (1 byte) | options (1 byte, see optionsFlags)
+const domainEncodingVersion = 1
+
+// bits of the options byte
+const (
+	optionWithoutPrecompute byte = 1 << iota
+	optionWithoutCosetTables
+	optionWithoutFourStep
+)
+
+// optionsFlags returns the options byte of the domain
+func (d *Domain) optionsFlags() byte {
+	var options byte
+	if d.withoutPrecompute {
+		options |= optionWithoutPrecompute
+	}
+	if d.withoutCosetTables {
+		options |= optionWithoutCosetTables
+	}
+	if d.withoutFourStep {
+		options |= optionWithoutFourStep
+	}
+	return options
+}
+
+// isOptionsMarker reports whether buf is the marker of the options field
+func isOptionsMarker(buf []byte) bool {
+	for _, b := range buf {
+		if b != 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
 // WriteTo writes a binary representation of the domain (without the precomputed twiddle factors)
 // to the provided writer
 func (d *Domain) WriteTo(w io.Writer) (int64, error) {
 
-	enc := curve.NewEncoder(w)
+	// the cardinality is encoded on 8 bytes, which may be more than an element
+	var bCardinality [8]byte
+	binary.BigEndian.PutUint64(bCardinality[:], d.Cardinality)
+	n, err := w.Write(bCardinality[:])
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
 
-	toEncode := []interface{}{d.Cardinality, &d.CardinalityInv, &d.Generator, &d.GeneratorInv, &d.FrMultiplicativeGen, &d.FrMultiplicativeGenInv}
+	var buf [fr.Bytes]byte
+	for i := range buf {
+		buf[i] = 0xFF
+	}
+	n, err = w.Write(buf[:])
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+	n, err = w.Write([]byte{domainEncodingVersion, d.optionsFlags()})
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	toEncode := []*fr.Element{&d.CardinalityInv, &d.Generator, &d.GeneratorInv, &d.FrMultiplicativeGen, &d.FrMultiplicativeGenInv}
 
 	for _, v := range toEncode {
-		if err := enc.Encode(v); err != nil {
-			return enc.BytesWritten(), err
+		buf = v.Bytes()
+		n, err = w.Write(buf[:])
+		written += int64(n)
+		if err != nil {
+			return written, err
 		}
 	}
 
-	return enc.BytesWritten(), nil
+	return written, nil
 }
 
 // ReadFrom attempts to decode a domain from Reader
 func (d *Domain) ReadFrom(r io.Reader) (int64, error) {
 
-	dec := curve.NewDecoder(r)
+	var bCardinality [8]byte
+	n, err := io.ReadFull(r, bCardinality[:])
+	read := int64(n)
+	if err != nil {
+		return read, err
+	}
+	*d = Domain{Cardinality: binary.BigEndian.Uint64(bCardinality[:])}
+
+	var buf [fr.Bytes]byte
+	if d.Cardinality&(d.Cardinality-1) != 0 {
+		var ok bool
+		if d.radices, ok = mixedRadices(d.Cardinality); !ok {
+			return read, fmt.Errorf("invalid domain cardinality %d", d.Cardinality)
+		}
+	}
 
-	toDecode := []interface{}{&d.Cardinality, &d.CardinalityInv, &d.Generator, &d.GeneratorInv, &d.FrMultiplicativeGen, &d.FrMultiplicativeGenInv}
+	toDecode := []*fr.Element{&d.CardinalityInv, &d.Generator, &d.GeneratorInv, &d.FrMultiplicativeGen, &d.FrMultiplicativeGenInv}
 
-	for _, v := range toDecode {
-		if err := dec.Decode(v); err != nil {
-			return dec.BytesRead(), err
+	for i, v := range toDecode {
+		n, err = io.ReadFull(r, buf[:])
+		read += int64(n)
+		if err != nil {
+			return read, err
+		}
+		if i == 0 && isOptionsMarker(buf[:]) {
+			var options [2]byte
+			n, err = io.ReadFull(r, options[:])
+			read += int64(n)
+			if err != nil {
+				return read, err
+			}
+			if options[0] != domainEncodingVersion {
+				return read, fmt.Errorf("unsupported domain encoding version %d", options[0])
+			}
+			if options[1]&^(optionWithoutPrecompute|optionWithoutCosetTables|optionWithoutFourStep) != 0 {
+				return read, fmt.Errorf("unknown domain options %#x", options[1])
+			}
+			d.withoutPrecompute = options[1]&optionWithoutPrecompute != 0
+			d.withoutCosetTables = options[1]&optionWithoutCosetTables != 0
+			d.withoutFourStep = options[1]&optionWithoutFourStep != 0
+
+			n, err = io.ReadFull(r, buf[:])
+			read += int64(n)
+			if err != nil {
+				return read, err
+			}
+		}
+		if err = v.SetBytesCanonical(buf[:]); err != nil {
+			return read, err
 		}
 	}
 
-	// twiddle factors
+	// twiddle factors and coset tables
 	d.preComputeTwiddles()
 
-	// store the bit reversed coset tables if needed
-	d.reverseCosetTables()
-
-	return dec.BytesRead(), nil
+	return read, nil
 }
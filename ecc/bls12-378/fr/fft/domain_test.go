@@ -20,11 +20,140 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
 )
 
 func TestDomainSerialization(t *testing.T) {
+	t.Run("power of 2", func(t *testing.T) {
+		testDomainSerialization(t, NewDomain(1<<6))
+	})
+	t.Run("mixed radix", func(t *testing.T) {
+		testDomainSerialization(t, NewMixedRadixDomain(1<<6+1))
+	})
+	t.Run("options", func(t *testing.T) {
+		for _, opts := range [][]DomainOption{{WithoutPrecompute()}, {WithoutCosetTables()}, {WithoutFourStep()}, {WithoutPrecompute(), WithoutCosetTables(), WithoutFourStep()}} {
+			testDomainSerialization(t, NewDomain(1<<6, opts...))
+			testDomainSerialization(t, NewMixedRadixDomain(1<<6+1, opts...))
+		}
+	})
+	t.Run("without options", func(t *testing.T) {
+		// domains serialized before the options field are still read
+		domain := NewDomain(1 << 6)
+		var buf bytes.Buffer
+		if _, err := domain.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		encoded := buf.Bytes()
+		legacy := append(append([]byte{}, encoded[:8]...), encoded[8+fr.Bytes+2:]...)
+
+		var reconstructed Domain
+		read, err := reconstructed.ReadFrom(bytes.NewReader(legacy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if read != int64(len(legacy)) || !reflect.DeepEqual(domain, &reconstructed) {
+			t.Fatal("domain serialized without options mismatch")
+		}
+
+		// a reader predating the options field fails on the marker, which it expects to be CardinalityInv
+		var e fr.Element
+		if err := e.SetBytesCanonical(encoded[8 : 8+fr.Bytes]); err == nil {
+			t.Fatal("the options marker should not be a canonical field element")
+		}
+	})
+}
+
+func TestDomainWithoutTables(t *testing.T) {
+	// a domain built field by field has no tables yet; they are allocated on first use
+	reference := NewDomain(1 << 6)
+	domain := Domain{
+		Cardinality:            reference.Cardinality,
+		CardinalityInv:         reference.CardinalityInv,
+		Generator:              reference.Generator,
+		GeneratorInv:           reference.GeneratorInv,
+		FrMultiplicativeGen:    reference.FrMultiplicativeGen,
+		FrMultiplicativeGenInv: reference.FrMultiplicativeGenInv,
+	}
+	n := int(reference.Cardinality)
+	expected := make([]fr.Element, n)
+	for i := range expected {
+		expected[i].SetRandom()
+	}
+	res := make([]fr.Element, n)
+	copy(res, expected)
+
+	reference.FFT(expected, DIF, true)
+	domain.FFT(res, DIF, true)
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatal("FFT mismatch")
+	}
+	reference.FFTInverse(expected, DIT, true)
+	domain.FFTInverse(res, DIT, true)
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatal("FFTInverse mismatch")
+	}
+}
+
+func TestDomainOptions(t *testing.T) {
+	const maxSize = 1 << 6
+	for _, newDomain := range []func(opts ...DomainOption) *Domain{
+		func(opts ...DomainOption) *Domain { return NewDomain(maxSize, opts...) },
+		func(opts ...DomainOption) *Domain { return NewMixedRadixDomain(maxSize+1, opts...) },
+	} {
+		reference := newDomain()
+		n := int(reference.Cardinality)
+		pol := make([]fr.Element, n)
+		for i := range pol {
+			pol[i].SetRandom()
+		}
+
+		for _, opts := range [][]DomainOption{{WithoutPrecompute()}, {WithoutCosetTables()}, {WithoutPrecompute(), WithoutCosetTables()}} {
+			domain := newDomain(opts...)
+			if domain.withoutPrecompute {
+				if domain.Twiddles != nil || domain.TwiddlesInv != nil || domain.CosetTable != nil {
+					t.Fatal("tables should not be precomputed")
+				}
+				// a copy shares the tables computed on first use
+				domainCopy := *domain
+				domainCopy.FFT(make([]fr.Element, n), DIF)
+				if domain.tables.twiddles == nil || domain.tables.twiddlesInv != nil {
+					t.Fatal("a FFT should only compute the forward twiddles")
+				}
+				if domain.Twiddles != nil {
+					t.Fatal("tables computed on first use should not be exposed")
+				}
+			}
+
+			for _, coset := range []bool{false, true} {
+				for _, decimation := range []Decimation{DIF, DIT} {
+					expected := make([]fr.Element, n)
+					res := make([]fr.Element, n)
+					copy(expected, pol)
+					copy(res, pol)
+
+					reference.FFT(expected, decimation, coset)
+					domain.FFT(res, decimation, coset)
+					if !reflect.DeepEqual(res, expected) {
+						t.Fatalf("cardinality %d, coset %t, decimation %d: FFT mismatch", n, coset, decimation)
+					}
+
+					reference.FFTInverse(expected, 1-decimation, coset)
+					domain.FFTInverse(res, 1-decimation, coset)
+					if !reflect.DeepEqual(res, expected) {
+						t.Fatalf("cardinality %d, coset %t, decimation %d: FFTInverse mismatch", n, coset, decimation)
+					}
+				}
+			}
+
+			if domain.withoutCosetTables && (domain.CosetTable != nil || domain.CosetTableInv != nil || domain.CosetTableReversed != nil || domain.CosetTableInvReversed != nil) {
+				t.Fatal("coset tables should not be computed")
+			}
+		}
+	}
+}
 
-	domain := NewDomain(1 << 6)
+func testDomainSerialization(t *testing.T, domain *Domain) {
 	var reconstructed Domain
 
 	var buf bytes.Buffer
@@ -0,0 +1,185 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"math/big"
+	"math/bits"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/utils"
+)
+
+// FFTG1 computes the discrete Fourier transform of the points a and stores the result in a,
+// with the same twiddles as FFT, acting on the points by scalar multiplication.
+// if decimation == DIT (decimation in time), the input must be in bit-reversed order
+// if decimation == DIF (decimation in frequency), the output will be in bit-reversed order
+// the domain cardinality must be a power of 2.
+func (domain *Domain) FFTG1(a []bls12378.G1Jac, decimation Decimation) {
+	if domain.radices != nil {
+		panic("fft: FFTG1 is only implemented on domains of cardinality a power of 2")
+	}
+	radix2FFTG1(a, domain.twiddles(), decimation, runtime.NumCPU())
+}
+
+// FFTInverseG1 computes the inverse discrete Fourier transform of the points a and stores the result in a.
+// if decimation == DIT (decimation in time), the input must be in bit-reversed order
+// if decimation == DIF (decimation in frequency), the output will be in bit-reversed order
+// the domain cardinality must be a power of 2.
+func (domain *Domain) FFTInverseG1(a []bls12378.G1Jac, decimation Decimation) {
+	if domain.radices != nil {
+		panic("fft: FFTInverseG1 is only implemented on domains of cardinality a power of 2")
+	}
+	numCPU := runtime.NumCPU()
+	radix2FFTG1(a, domain.twiddlesInv(), decimation, numCPU)
+
+	// scale by CardinalityInv
+	var cardinalityInv big.Int
+	domain.CardinalityInv.BigInt(&cardinalityInv)
+	utils.Parallelize(len(a), func(start, end int) {
+		for i := start; i < end; i++ {
+			a[i].ScalarMultiplication(&a[i], &cardinalityInv)
+		}
+	}, numCPU)
+}
+
+// BitReverseG1 applies the bit-reversal permutation to a.
+// len(a) must be a power of 2.
+func BitReverseG1(a []bls12378.G1Jac) {
+	n := uint64(len(a))
+	nn := uint64(64 - bits.TrailingZeros64(n))
+
+	for i := uint64(0); i < n; i++ {
+		irev := bits.Reverse64(i) >> nn
+		if irev > i {
+			a[i], a[irev] = a[irev], a[i]
+		}
+	}
+}
+
+// radix2FFTG1 computes recursively the FFT of the points a, of size a power of 2, with the given twiddles.
+// DIF takes a in natural order and outputs it in bit-reversed order, DIT does the opposite.
+func radix2FFTG1(a []bls12378.G1Jac, twiddles [][]fr.Element, decimation Decimation, numCPU int) {
+	switch decimation {
+	case DIF:
+		difFFTG1(a, twiddles, 0, maxSplits(numCPU), numCPU, nil)
+	case DIT:
+		ditFFTG1(a, twiddles, 0, maxSplits(numCPU), numCPU, nil)
+	default:
+		panic("not implemented")
+	}
+}
+
+func difFFTG1(a []bls12378.G1Jac, twiddles [][]fr.Element, stage, maxSplits, numCPU int, chDone chan struct{}) {
+	if chDone != nil {
+		defer close(chDone)
+	}
+
+	n := len(a)
+	if n == 1 {
+		return
+	}
+	m := n >> 1
+
+	butterfly := func(start, end int) {
+		var w big.Int
+		for i := start; i < end; i++ {
+			butterflyG1(&a[i], &a[i+m])
+			if i != 0 {
+				twiddles[stage][i].BigInt(&w)
+				a[i+m].ScalarMultiplication(&a[i+m], &w)
+			}
+		}
+	}
+
+	// if stage < maxSplits, we parallelize this butterfly
+	// but we have only numCPU / stage cpus available
+	if (m > butterflyThreshold) && (stage < maxSplits) {
+		// 1 << stage == estimated used CPUs
+		utils.Parallelize(m, butterfly, numCPU/(1<<stage))
+	} else {
+		butterfly(0, m)
+	}
+
+	if m == 1 {
+		return
+	}
+
+	nextStage := stage + 1
+	if stage < maxSplits {
+		chDone := make(chan struct{}, 1)
+		go difFFTG1(a[m:n], twiddles, nextStage, maxSplits, numCPU, chDone)
+		difFFTG1(a[0:m], twiddles, nextStage, maxSplits, numCPU, nil)
+		<-chDone
+	} else {
+		difFFTG1(a[0:m], twiddles, nextStage, maxSplits, numCPU, nil)
+		difFFTG1(a[m:n], twiddles, nextStage, maxSplits, numCPU, nil)
+	}
+}
+
+func ditFFTG1(a []bls12378.G1Jac, twiddles [][]fr.Element, stage, maxSplits, numCPU int, chDone chan struct{}) {
+	if chDone != nil {
+		defer close(chDone)
+	}
+
+	n := len(a)
+	if n == 1 {
+		return
+	}
+	m := n >> 1
+
+	nextStage := stage + 1
+	if stage < maxSplits {
+		chDone := make(chan struct{}, 1)
+		go ditFFTG1(a[m:], twiddles, nextStage, maxSplits, numCPU, chDone)
+		ditFFTG1(a[0:m], twiddles, nextStage, maxSplits, numCPU, nil)
+		<-chDone
+	} else {
+		ditFFTG1(a[0:m], twiddles, nextStage, maxSplits, numCPU, nil)
+		ditFFTG1(a[m:n], twiddles, nextStage, maxSplits, numCPU, nil)
+	}
+
+	butterfly := func(start, end int) {
+		var w big.Int
+		for k := start; k < end; k++ {
+			if k != 0 {
+				twiddles[stage][k].BigInt(&w)
+				a[k+m].ScalarMultiplication(&a[k+m], &w)
+			}
+			butterflyG1(&a[k], &a[k+m])
+		}
+	}
+
+	// if stage < maxSplits, we parallelize this butterfly
+	// but we have only numCPU / stage cpus available
+	if (m > butterflyThreshold) && (stage < maxSplits) {
+		// 1 << stage == estimated used CPUs
+		utils.Parallelize(m, butterfly, numCPU/(1<<stage))
+	} else {
+		butterfly(0, m)
+	}
+}
+
+// butterflyG1 computes (a, b) ← (a + b, a - b)
+func butterflyG1(a, b *bls12378.G1Jac) {
+	t := *a
+	a.AddAssign(b)
+	t.SubAssign(b)
+	*b = t
+}
@@ -18,11 +18,14 @@ package fft
 
 import (
 	"math/big"
+	"reflect"
+	"runtime"
 	"strconv"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
@@ -226,6 +229,288 @@ func TestFFT(t *testing.T) {
 
 }
 
+func TestMixedRadixFFT(t *testing.T) {
+	qMinusOne := fr.Modulus()
+	qMinusOne.Sub(qMinusOne, big.NewInt(1))
+
+	for _, m := range []uint64{3, 6, 12, 15, 20, 45, 100, 257, 1000} {
+		domain := NewMixedRadixDomain(m)
+		n := domain.Cardinality
+		if n < m || new(big.Int).Mod(qMinusOne, new(big.Int).SetUint64(n)).Sign() != 0 {
+			t.Fatalf("m = %d: invalid cardinality %d", m, n)
+		}
+		if n >= ecc.NextPowerOfTwo(m) && n&(n-1) != 0 {
+			t.Fatalf("m = %d: cardinality %d is larger than the power of 2 domain", m, n)
+		}
+		isPowerOfTwo := n&(n-1) == 0
+
+		pol := make([]fr.Element, n)
+		backupPol := make([]fr.Element, n)
+		for i := range pol {
+			pol[i].SetRandom()
+		}
+		copy(backupPol, pol)
+
+		for _, coset := range []bool{false, true} {
+			domain.FFT(pol, DIF, coset)
+			if isPowerOfTwo {
+				BitReverse(pol)
+			}
+
+			sample := fr.One()
+			if coset {
+				sample = domain.FrMultiplicativeGen
+			}
+			for i := range pol {
+				eval := evaluatePolynomial(backupPol, sample)
+				if !eval.Equal(&pol[i]) {
+					t.Fatalf("m = %d, coset = %v: FFT mismatch at index %d", m, coset, i)
+				}
+				sample.Mul(&sample, &domain.Generator)
+			}
+
+			if isPowerOfTwo {
+				BitReverse(pol)
+			}
+			domain.FFTInverse(pol, DIT, coset)
+			for i := range pol {
+				if !pol[i].Equal(&backupPol[i]) {
+					t.Fatalf("m = %d, coset = %v: FFTInverse(FFT) != id at index %d", m, coset, i)
+				}
+			}
+		}
+	}
+}
+
+func TestFFTBatch(t *testing.T) {
+	for _, domain := range []*Domain{NewDomain(1 << 8), NewMixedRadixDomain(1<<8 + 1)} {
+		n := int(domain.Cardinality)
+		for _, nbPolys := range []int{0, 1, 3, 2*runtime.NumCPU() + 1} {
+			for _, decimation := range []Decimation{DIF, DIT} {
+				for _, coset := range []bool{false, true} {
+					polys := make([][]fr.Element, nbPolys)
+					expected := make([][]fr.Element, nbPolys)
+					for i := range polys {
+						polys[i] = make([]fr.Element, n)
+						expected[i] = make([]fr.Element, n)
+						for j := range polys[i] {
+							polys[i][j].SetRandom()
+						}
+						copy(expected[i], polys[i])
+						domain.FFT(expected[i], decimation, coset)
+					}
+
+					domain.FFTBatch(polys, decimation, coset)
+					for i := range polys {
+						for j := range polys[i] {
+							if !polys[i][j].Equal(&expected[i][j]) {
+								t.Fatalf("cardinality %d, %d polynomials, decimation %d, coset %v: FFTBatch mismatch", n, nbPolys, decimation, coset)
+							}
+						}
+						domain.FFTInverse(expected[i], 1-decimation, coset)
+					}
+
+					domain.FFTInverseBatch(polys, 1-decimation, coset)
+					for i := range polys {
+						for j := range polys[i] {
+							if !polys[i][j].Equal(&expected[i][j]) {
+								t.Fatalf("cardinality %d, %d polynomials, decimation %d, coset %v: FFTInverseBatch mismatch", n, nbPolys, decimation, coset)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestFFTShifted(t *testing.T) {
+	for _, domain := range []*Domain{NewDomain(1 << 6), NewMixedRadixDomain(1<<6 + 1)} {
+		n := int(domain.Cardinality)
+		pol := make([]fr.Element, n)
+		backupPol := make([]fr.Element, n)
+		for i := range pol {
+			pol[i].SetRandom()
+		}
+		copy(backupPol, pol)
+		isPowerOfTwo := domain.radices == nil
+
+		var shift fr.Element
+		shift.SetRandom()
+
+		for _, decimation := range []Decimation{DIF, DIT} {
+			if decimation == DIT && isPowerOfTwo {
+				BitReverse(pol)
+			}
+			domain.FFTShifted(pol, decimation, &shift)
+			if decimation == DIF && isPowerOfTwo {
+				BitReverse(pol)
+			}
+
+			sample := shift
+			for i := range pol {
+				eval := evaluatePolynomial(backupPol, sample)
+				if !eval.Equal(&pol[i]) {
+					t.Fatalf("cardinality %d, decimation %d: FFTShifted mismatch at index %d", n, decimation, i)
+				}
+				sample.Mul(&sample, &domain.Generator)
+			}
+
+			// inverse, with the other decimation
+			if decimation == DIF && isPowerOfTwo {
+				BitReverse(pol)
+			}
+			domain.FFTInverseShifted(pol, 1-decimation, &shift)
+			if decimation == DIT && isPowerOfTwo {
+				BitReverse(pol)
+			}
+			for i := range pol {
+				if !pol[i].Equal(&backupPol[i]) {
+					t.Fatalf("cardinality %d, decimation %d: FFTInverseShifted(FFTShifted) != id at index %d", n, decimation, i)
+				}
+			}
+		}
+
+		// FFT on the default coset
+		expected := make([]fr.Element, n)
+		copy(expected, pol)
+		domain.FFT(expected, DIF, true)
+		domain.FFTShifted(pol, DIF, &domain.FrMultiplicativeGen)
+		for i := range pol {
+			if !pol[i].Equal(&expected[i]) {
+				t.Fatalf("cardinality %d: FFTShifted(FrMultiplicativeGen) != FFT on coset", n)
+			}
+		}
+	}
+}
+
+func TestLDE(t *testing.T) {
+	for _, domain := range []*Domain{NewDomain(1 << 4), NewMixedRadixDomain(1<<4 + 1)} {
+		n := int(domain.Cardinality)
+		for _, blowup := range []int{1, 2, 4} {
+			pol := make([]fr.Element, n-1)
+			for i := range pol {
+				pol[i].SetRandom()
+			}
+			lde := domain.LDE(pol, blowup)
+			if len(lde) != blowup*n {
+				t.Fatalf("cardinality %d, blowup %d: wrong LDE size %d", n, blowup, len(lde))
+			}
+
+			// ω = CosetShift(blowup, 1) / g has order blowup⋅n and ω^blowup = Generator
+			omega := domain.CosetShift(blowup, 1)
+			omega.Mul(&omega, &domain.FrMultiplicativeGenInv)
+			var check fr.Element
+			check.Exp(omega, big.NewInt(int64(blowup)))
+			if !check.Equal(&domain.Generator) {
+				t.Fatalf("cardinality %d, blowup %d: ω^blowup != Generator", n, blowup)
+			}
+
+			sample := domain.FrMultiplicativeGen
+			for i := range lde {
+				eval := evaluatePolynomial(pol, sample)
+				if !eval.Equal(&lde[i]) {
+					t.Fatalf("cardinality %d, blowup %d: LDE mismatch at index %d", n, blowup, i)
+				}
+				sample.Mul(&sample, &omega)
+			}
+			if !sample.Equal(&domain.FrMultiplicativeGen) {
+				t.Fatalf("cardinality %d, blowup %d: ω doesn't have order blowup⋅n", n, blowup)
+			}
+		}
+	}
+}
+
+func TestFourStepFFT(t *testing.T) {
+	for logSize := 2; logSize <= 11; logSize++ {
+		domain := NewDomain(1 << logSize)
+		n := int(domain.Cardinality)
+		pol := make([]fr.Element, n)
+		for i := range pol {
+			pol[i].SetRandom()
+		}
+
+		for _, twiddles := range [][][]fr.Element{domain.Twiddles, domain.TwiddlesInv} {
+			for _, decimation := range []Decimation{DIF, DIT} {
+				expected := make([]fr.Element, n)
+				copy(expected, pol)
+				if decimation == DIF {
+					difFFT(expected, twiddles, 0, -1, 1, nil)
+				} else {
+					ditFFT(expected, twiddles, 0, -1, 1, nil)
+				}
+
+				res := make([]fr.Element, n)
+				copy(res, pol)
+				fourStepFFT(res, twiddles, decimation, runtime.NumCPU())
+				for i := range res {
+					if !res[i].Equal(&expected[i]) {
+						t.Fatalf("size %d, decimation %d: four-step FFT mismatch at index %d", n, decimation, i)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestFFTWithFourStep(t *testing.T) {
+	defer func(threshold int) { fourStepThreshold = threshold }(fourStepThreshold)
+	fourStepThreshold = 1 << 10
+
+	reference := NewDomain(uint64(fourStepThreshold), WithoutFourStep())
+	domain := NewDomain(uint64(fourStepThreshold))
+	n := int(domain.Cardinality)
+
+	// a few polynomials, so that FFTBatch runs concurrent four-step FFTs sharing the scratch buffers
+	polys := make([][]fr.Element, 3)
+	expected := make([][]fr.Element, len(polys))
+	for i := range polys {
+		polys[i] = make([]fr.Element, n)
+		expected[i] = make([]fr.Element, n)
+		for j := range polys[i] {
+			polys[i][j].SetRandom()
+		}
+		copy(expected[i], polys[i])
+	}
+
+	for _, decimation := range []Decimation{DIF, DIT} {
+		for i := range polys {
+			reference.FFT(expected[i], decimation, true)
+		}
+		domain.FFTBatch(polys, decimation, true)
+		if !reflect.DeepEqual(polys, expected) {
+			t.Fatalf("decimation %d: FFTBatch mismatch", decimation)
+		}
+
+		for i := range polys {
+			reference.FFTInverse(expected[i], 1-decimation, true)
+			domain.FFTInverse(polys[i], 1-decimation, true)
+		}
+		if !reflect.DeepEqual(polys, expected) {
+			t.Fatalf("decimation %d: FFTInverse mismatch", decimation)
+		}
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	for _, dims := range [][2]int{{1, 1}, {3, 5}, {16, 32}, {33, 17}} {
+		nbRows, nbColumns := dims[0], dims[1]
+		src := make([]fr.Element, nbRows*nbColumns)
+		for i := range src {
+			src[i].SetUint64(uint64(i))
+		}
+		dst := make([]fr.Element, len(src))
+		transpose(dst, src, nbRows, nbColumns, runtime.NumCPU())
+		for r := 0; r < nbRows; r++ {
+			for c := 0; c < nbColumns; c++ {
+				if !dst[c*nbRows+r].Equal(&src[r*nbColumns+c]) {
+					t.Fatalf("%d×%d: transpose mismatch at (%d, %d)", nbRows, nbColumns, r, c)
+				}
+			}
+		}
+	}
+}
+
 // --------------------------------------------------------------------
 // benches
 func BenchmarkBitReverse(b *testing.B) {
@@ -279,6 +564,85 @@ func BenchmarkFFT(b *testing.B) {
 
 }
 
+func BenchmarkMixedRadixFFT(b *testing.B) {
+	const maxSize = 1 << 20
+
+	pol := make([]fr.Element, maxSize)
+	pol[0].SetRandom()
+	for i := 1; i < maxSize; i++ {
+		pol[i] = pol[i-1]
+	}
+
+	for i := 8; i < 20; i++ {
+		domain := NewMixedRadixDomain(1<<i + 1)
+		sizeDomain := int(domain.Cardinality)
+		if sizeDomain > maxSize {
+			continue
+		}
+		b.Run("fft 2**"+strconv.Itoa(i)+"+1 (size "+strconv.Itoa(sizeDomain)+")", func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				domain.FFT(pol[:sizeDomain], DIF, false)
+			}
+		})
+	}
+}
+
+func BenchmarkFFTBatch(b *testing.B) {
+	const sizeDomain = 1 << 16
+	const nbPolys = 32
+
+	polys := make([][]fr.Element, nbPolys)
+	for i := range polys {
+		polys[i] = make([]fr.Element, sizeDomain)
+		polys[i][0].SetRandom()
+		for j := 1; j < sizeDomain; j++ {
+			polys[i][j] = polys[i][j-1]
+		}
+	}
+	domain := NewDomain(sizeDomain)
+
+	b.Run("sequential", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			for i := range polys {
+				domain.FFT(polys[i], DIF, true)
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			domain.FFTBatch(polys, DIF, true)
+		}
+	})
+}
+
+func BenchmarkFourStepFFT(b *testing.B) {
+	const maxSize = 1 << 22
+
+	pol := make([]fr.Element, maxSize)
+	pol[0].SetRandom()
+	for i := 1; i < maxSize; i++ {
+		pol[i] = pol[i-1]
+	}
+
+	for i := 18; i <= 22; i++ {
+		sizeDomain := 1 << i
+		domain := NewDomain(uint64(sizeDomain))
+		b.Run("recursive 2**"+strconv.Itoa(i)+"bits", func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				difFFT(pol[:sizeDomain], domain.Twiddles, 0, maxSplits(runtime.NumCPU()), runtime.NumCPU(), nil)
+			}
+		})
+		b.Run("four-step 2**"+strconv.Itoa(i)+"bits", func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				fourStepFFT(pol[:sizeDomain], domain.Twiddles, DIF, runtime.NumCPU())
+			}
+		})
+	}
+}
+
 func BenchmarkFFTDITCosetReference(b *testing.B) {
 	const maxSize = 1 << 20
 
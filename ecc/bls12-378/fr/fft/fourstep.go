@@ -0,0 +1,145 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/utils"
+)
+
+// fourStepThreshold is the size from which power of 2 FFTs use the four-step algorithm, unless the
+// domain was created WithoutFourStep; below it, the recursive FFT fits in cache well enough and is faster
+// (see BenchmarkFourStepFFT). A variable for the tests.
+var fourStepThreshold = 1 << 22
+
+// transposeBlockSize is the size of the square tiles the transpositions work on
+const transposeBlockSize = 16
+
+// fourStepBuffers holds the scratch space of fourStepFFT (*[]fr.Element), reused across calls
+// and shared by the polynomials of FFTBatch
+var fourStepBuffers sync.Pool
+
+// fourStepFFT computes in place the FFT of a (len(a) a power of 2) using twiddles (Twiddles or
+// TwiddlesInv of the domain of size len(a)); as with difFFT and ditFFT, DIF outputs the result in
+// bit-reversed order and DIT takes its input in bit-reversed order.
+//
+// With n₁⋅n₂ = len(a), the FFT is split in n₁ FFTs of size n₂ and n₂ FFTs of size n₁ (Bailey's
+// four-step algorithm), computed on contiguous rows of √len(a) elements:
+//
+//	DIF: X[k₂ + n₂⋅k₁] = Σᵢ₁ ω₁^(i₁k₁) ⋅ ω^(i₁k₂) ⋅ Σᵢ₂ ω₂^(i₂k₂) ⋅ a[i₁ + n₁⋅i₂]
+//	DIT: X[k₁ + n₁⋅k₂] = Σᵢ₂ ω₂^(i₂k₂) ⋅ ω^(i₂k₁) ⋅ Σᵢ₁ ω₁^(i₁k₁) ⋅ a[i₂ + n₂⋅i₁]
+//
+// where ω₁ = ω^n₂ and ω₂ = ω^n₁. The bit-reversal permutations are absorbed by the row FFTs and the
+// two transpositions, which are done on cache-sized tiles.
+func fourStepFFT(a []fr.Element, twiddles [][]fr.Element, decimation Decimation, numCPU int) {
+	n := len(a)
+	logN := bits.TrailingZeros(uint(n))
+	n1 := 1 << (logN / 2)
+	n2 := n / n1
+
+	bufPtr, _ := fourStepBuffers.Get().(*[]fr.Element)
+	if bufPtr == nil || cap(*bufPtr) < n {
+		b := make([]fr.Element, n)
+		bufPtr = &b
+	}
+	defer fourStepBuffers.Put(bufPtr)
+	buf := (*bufPtr)[:n]
+
+	// the FFT of size m uses the twiddles of the last stages of the FFT of size n
+	rowsFFT := func(a []fr.Element, rowSize int, onRow func(i int, row, powers []fr.Element)) {
+		rowTwiddles := twiddles[logN-bits.TrailingZeros(uint(rowSize)):]
+		utils.Parallelize(n/rowSize, func(start, end int) {
+			powers := make([]fr.Element, rowSize)
+			for i := start; i < end; i++ {
+				row := a[i*rowSize : (i+1)*rowSize]
+				if decimation == DIF {
+					difFFT(row, rowTwiddles, 0, -1, 1, nil)
+				} else {
+					ditFFT(row, rowTwiddles, 0, -1, 1, nil)
+				}
+				if onRow != nil {
+					onRow(i, row, powers)
+				}
+			}
+		}, numCPU)
+	}
+
+	if decimation == DIF {
+		// a is a n₂ × n₁ matrix: its columns are the polynomials of the first FFTs
+		transpose(buf, a, n2, n1, numCPU)
+		shift := uint64(64 - bits.TrailingZeros(uint(n2)))
+		rowsFFT(buf, n2, func(i1 int, row, powers []fr.Element) {
+			// row[j] is at k₂ = bitReverse(j), multiply it by ω^(i₁k₂); ω^i₁ = twiddles[0][i₁] since n₁ ≤ n/2
+			precomputeExpTableChunk(twiddles[0][i1], 0, powers)
+			for j := 1; j < len(row); j++ {
+				row[j].Mul(&row[j], &powers[bits.Reverse64(uint64(j))>>shift])
+			}
+		})
+		transpose(a, buf, n1, n2, numCPU)
+		rowsFFT(a, n1, nil)
+		return
+	}
+
+	// a is a n₂ × n₁ matrix: row j holds the bit-reversed polynomial of the first FFT for i₂ = bitReverse(j)
+	shift := uint64(64 - bits.TrailingZeros(uint(n2)))
+	rowsFFT(a, n1, func(j int, row, powers []fr.Element) {
+		// multiply row[k₁] by ω^(i₂k₁)
+		i2 := bits.Reverse64(uint64(j)) >> shift
+		if j == 0 {
+			return
+		}
+		w := twiddles[0][i2]
+		t := w
+		row[1].Mul(&row[1], &t)
+		for k1 := 2; k1 < len(row); k1++ {
+			t.Mul(&t, &w)
+			row[k1].Mul(&row[k1], &t)
+		}
+	})
+	transpose(buf, a, n2, n1, numCPU)
+	rowsFFT(buf, n2, nil)
+	transpose(a, buf, n1, n2, numCPU)
+}
+
+// transpose writes in dst the transpose of the nbRows × nbColumns row-major matrix src
+func transpose(dst, src []fr.Element, nbRows, nbColumns int, numCPU int) {
+	const b = transposeBlockSize
+	nbBlockRows := (nbRows + b - 1) / b
+	utils.Parallelize(nbBlockRows, func(start, end int) {
+		for br := start; br < end; br++ {
+			r0 := br * b
+			r1 := r0 + b
+			if r1 > nbRows {
+				r1 = nbRows
+			}
+			for c0 := 0; c0 < nbColumns; c0 += b {
+				c1 := c0 + b
+				if c1 > nbColumns {
+					c1 = nbColumns
+				}
+				for r := r0; r < r1; r++ {
+					for c := c0; c < c1; c++ {
+						dst[c*nbRows+r] = src[r*nbColumns+c]
+					}
+				}
+			}
+		}
+	}, numCPU)
+}
@@ -0,0 +1,267 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/utils"
+)
+
+// mixedRadixPrimes are the radices supported by the mixed-radix FFT.
+// A radix-p stage costs p² multiplications per group of p elements, so only small primes are worth it.
+var mixedRadixPrimes = [...]uint64{2, 3, 5, 7, 11, 13, 17, 19}
+
+// NewMixedRadixDomain returns a subgroup of cardinality n, where n is the smallest divisor of q-1
+// (q being the field modulus) such that n >= m and all prime factors of n are in {2, 3, 5, ..., 19}.
+//
+// This avoids rounding m up to the next power of 2 when the multiplicative group of the field
+// has small odd-order subgroups (e.g. 2²⁰+1 fits in a domain of size 3⋅2¹⁹).
+//
+// If n is a power of 2, the returned domain is NewDomain(n). Otherwise, FFT and FFTInverse
+// take and return values in natural order, whatever the decimation.
+//
+// opts are as in NewDomain.
+func NewMixedRadixDomain(m uint64, opts ...DomainOption) *Domain {
+	n, ok := smallestSmoothDivisor(m)
+	if !ok {
+		panic(fmt.Sprintf("m (%d) is too big: no mixed-radix subgroup of size >= m exists", m))
+	}
+	if n&(n-1) == 0 {
+		return NewDomain(n, opts...)
+	}
+	radices, _ := mixedRadices(n)
+
+	domain := &Domain{Cardinality: n, radices: radices}
+	for _, opt := range opts {
+		opt(domain)
+	}
+	domain.FrMultiplicativeGen.SetUint64(22)
+	domain.FrMultiplicativeGenInv.Inverse(&domain.FrMultiplicativeGen)
+
+	// Generator = FrMultiplicativeGen^((q-1)/n) has order n
+	var e big.Int
+	e.Sub(fr.Modulus(), big.NewInt(1)).Div(&e, new(big.Int).SetUint64(n))
+	domain.Generator.Exp(domain.FrMultiplicativeGen, &e)
+	for i, p := range radices {
+		if i > 0 && radices[i-1] == p {
+			continue
+		}
+		var t fr.Element
+		t.Exp(domain.Generator, new(big.Int).SetUint64(n/p))
+		if t.IsOne() {
+			panic("the multiplicative generator does not generate the multiplicative group of the field")
+		}
+	}
+	domain.GeneratorInv.Inverse(&domain.Generator)
+	domain.CardinalityInv.SetUint64(n).Inverse(&domain.CardinalityInv)
+
+	domain.preComputeTwiddles()
+
+	return domain
+}
+
+// smallestSmoothDivisor returns the smallest divisor of q-1 greater or equal to m
+// whose prime factors are all in mixedRadixPrimes.
+func smallestSmoothDivisor(m uint64) (uint64, bool) {
+	var qMinusOne big.Int
+	qMinusOne.Sub(fr.Modulus(), big.NewInt(1))
+
+	// maximal power of each prime dividing q-1
+	var maxExp [len(mixedRadixPrimes)]int
+	var r, mod, bp big.Int
+	for i, p := range mixedRadixPrimes {
+		bp.SetUint64(p)
+		r.Set(&qMinusOne)
+		for maxExp[i] < 64 && mod.Mod(&r, &bp).Sign() == 0 {
+			r.Div(&r, &bp)
+			maxExp[i]++
+		}
+	}
+
+	best := uint64(0)
+	var search func(i int, n uint64)
+	search = func(i int, n uint64) {
+		if n >= m {
+			if best == 0 || n < best {
+				best = n
+			}
+			return
+		}
+		if i == len(mixedRadixPrimes) {
+			return
+		}
+		for e := 0; e <= maxExp[i]; e++ {
+			search(i+1, n)
+			hi, lo := bits.Mul64(n, mixedRadixPrimes[i])
+			if hi != 0 {
+				return
+			}
+			n = lo
+		}
+	}
+	search(0, 1)
+
+	return best, best != 0
+}
+
+// mixedRadices returns the prime factors of n in decreasing order, if they are all in mixedRadixPrimes.
+// Large radices come first so that the mixed-radix FFT applies them on the fewest, largest sub-transforms.
+func mixedRadices(n uint64) ([]uint64, bool) {
+	if n == 0 {
+		return nil, false
+	}
+	var radices []uint64
+	for i := len(mixedRadixPrimes) - 1; i >= 0; i-- {
+		p := mixedRadixPrimes[i]
+		for n%p == 0 {
+			radices = append(radices, p)
+			n /= p
+		}
+	}
+	return radices, n == 1
+}
+
+// mixedRadixTwiddles returns the twiddle factors of each stage of the mixed-radix FFT with root of unity omega
+func (d *Domain) mixedRadixTwiddles(omega fr.Element) [][]fr.Element {
+	// the sub-transforms of stage i have size nᵢ = n / (p₀⋯pᵢ₋₁) and radix pᵢ;
+	// t[i][j] = ωᵢʲ for j ≤ (pᵢ-1)⋅nᵢ/pᵢ, with ωᵢ a primitive nᵢ-th root of unity
+	t := make([][]fr.Element, len(d.radices))
+	size := d.Cardinality
+	for i, p := range d.radices {
+		m := size / p
+		t[i] = make([]fr.Element, (p-1)*m+1)
+		t[i][0] = fr.One()
+		for j := 1; j < len(t[i]); j++ {
+			t[i][j].Mul(&t[i][j-1], &omega)
+		}
+		// ωᵢ₊₁ = ωᵢ^pᵢ
+		omega.Exp(omega, new(big.Int).SetUint64(p))
+		size = m
+	}
+	return t
+}
+
+// mixedRadixFFT computes the discrete Fourier transform of a in place, in natural order,
+// using the per-stage twiddles of the domain (Twiddles for the FFT, TwiddlesInv for the inverse)
+// and up to numCPU goroutines.
+func (d *Domain) mixedRadixFFT(a []fr.Element, twiddles [][]fr.Element, numCPU int) {
+	in := make([]fr.Element, len(a))
+	copy(in, a)
+	mixedRadixFFT(a, in, 1, d.radices, twiddles, 0, maxSplits(numCPU), numCPU)
+}
+
+// mixedRadixFFT writes in out the discrete Fourier transform of
+// in[0], in[stride], ..., in[(len(out)-1)⋅stride].
+//
+// It splits the input in p = radices[stage] interleaved sub-sequences, transforms them recursively
+// and recombines the results with radix-p butterflies (Cooley-Tukey, decimation in time).
+func mixedRadixFFT(out, in []fr.Element, stride int, radices []uint64, twiddles [][]fr.Element, stage, maxSplits, numCPU int) {
+	n := len(out)
+	if n == 1 {
+		out[0] = in[0]
+		return
+	}
+	p := int(radices[stage])
+	m := n / p
+
+	// sub transforms; the s-th one goes to out[s⋅m:(s+1)⋅m]
+	if n == 2 {
+		out[0], out[1] = in[0], in[stride]
+		fr.Butterfly(&out[0], &out[1])
+		return
+	}
+	if m == 1 {
+		for s := 0; s < p; s++ {
+			out[s] = in[s*stride]
+		}
+	} else if stage < maxSplits {
+		var wg sync.WaitGroup
+		wg.Add(p - 1)
+		for s := 1; s < p; s++ {
+			go func(s int) {
+				mixedRadixFFT(out[s*m:(s+1)*m], in[s*stride:], stride*p, radices, twiddles, stage+1, maxSplits, numCPU)
+				wg.Done()
+			}(s)
+		}
+		mixedRadixFFT(out[:m], in, stride*p, radices, twiddles, stage+1, maxSplits, numCPU)
+		wg.Wait()
+	} else {
+		for s := 0; s < p; s++ {
+			mixedRadixFFT(out[s*m:(s+1)*m], in[s*stride:], stride*p, radices, twiddles, stage+1, maxSplits, numCPU)
+		}
+	}
+
+	// out[k + u⋅m] = ∑ₛ ωˢᵏ⋅out[k + s⋅m]⋅ωₚˢᵘ where ω = twiddles[stage][1] has order n and ωₚ = ωᵐ has order p
+	tw := twiddles[stage]
+	butterflies := func(start, end int) {
+		if p == 2 {
+			if start == 0 {
+				fr.Butterfly(&out[0], &out[m])
+				start = 1
+			}
+			for k := start; k < end; k++ {
+				out[k+m].Mul(&out[k+m], &tw[k])
+				fr.Butterfly(&out[k], &out[k+m])
+			}
+			return
+		}
+		if p == 3 {
+			// ω₃² = -1 - ω₃, so
+			// out[k+m] = (t₀ - t₂) + ω₃⋅(t₁ - t₂) and out[k+2m] = (t₀ - t₁) - ω₃⋅(t₁ - t₂)
+			w3 := &tw[m]
+			var t1, t2, d fr.Element
+			for k := start; k < end; k++ {
+				t1.Mul(&out[k+m], &tw[k])
+				t2.Mul(&out[k+2*m], &tw[2*k])
+				d.Sub(&t1, &t2).Mul(&d, w3)
+				out[k+m].Sub(&out[k], &t2).Add(&out[k+m], &d)
+				out[k+2*m].Sub(&out[k], &t1).Sub(&out[k+2*m], &d)
+				out[k].Add(&out[k], &t1).Add(&out[k], &t2)
+			}
+			return
+		}
+		t := make([]fr.Element, p)
+		var tmp fr.Element
+		for k := start; k < end; k++ {
+			t[0] = out[k]
+			for s := 1; s < p; s++ {
+				t[s].Mul(&out[k+s*m], &tw[s*k])
+			}
+			// u = 0
+			for s := 1; s < p; s++ {
+				out[k].Add(&out[k], &t[s])
+			}
+			for u := 1; u < p; u++ {
+				out[k+u*m] = t[0]
+				for s := 1; s < p; s++ {
+					tmp.Mul(&t[s], &tw[((s*u)%p)*m])
+					out[k+u*m].Add(&out[k+u*m], &tmp)
+				}
+			}
+		}
+	}
+	if m > butterflyThreshold && stage < maxSplits {
+		utils.Parallelize(m, butterflies, numCPU/(1<<stage))
+	} else {
+		butterflies(0, m)
+	}
+}
@@ -121,10 +121,8 @@ func BuildRatioShuffledVectors(numerator, denominator []*Polynomial, beta fr.Ele
 
 	}
 
-	t = fr.BatchInvert(t)
-	for i := 1; i < n; i++ {
-		coeffs[i].Mul(&coeffs[i], &t[i])
-	}
+	fr.BatchInvertInPlace(t)
+	fr.Vector(coeffs[1:n]).Mul(coeffs[1:n], t[1:n])
 
 	res := NewPolynomial(&coeffs, expectedForm)
 
@@ -218,10 +216,8 @@ func BuildRatioCopyConstraint(
 		t[i+1].Mul(&t[i+1], &t[i])
 	}
 
-	t = fr.BatchInvert(t)
-	for i := 1; i < n; i++ {
-		coeffs[i].Mul(&coeffs[i], &t[i])
-	}
+	fr.BatchInvertInPlace(t)
+	fr.Vector(coeffs[1:n]).Mul(coeffs[1:n], t[1:n])
 
 	res := NewPolynomial(&coeffs, expectedForm)
 	// at this stage the result is in Lagrange form, Regular layout
@@ -0,0 +1,166 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+)
+
+// multiPointsInstance returns random polynomials of various sizes, their digests, and sets of points
+// of various sizes, some shared between polynomials
+func multiPointsInstance(t *testing.T) ([][]fr.Element, []Digest, [][]fr.Element) {
+	sizes := []int{60, 1, 33, 200, 2}
+	nbPoints := []int{1, 3, 2, 5, 2}
+
+	shared := randomPolynomial(5)
+	polynomials := make([][]fr.Element, len(sizes))
+	digests := make([]Digest, len(sizes))
+	points := make([][]fr.Element, len(sizes))
+	for i := range sizes {
+		polynomials[i] = randomPolynomial(sizes[i])
+		var err error
+		if digests[i], err = Commit(polynomials[i], testSRS); err != nil {
+			t.Fatal(err)
+		}
+		points[i] = randomPolynomial(nbPoints[i])
+		copy(points[i], shared[i:i+1])
+	}
+	return polynomials, digests, points
+}
+
+func TestBatchOpenMultiPoints(t *testing.T) {
+
+	polynomials, digests, points := multiPointsInstance(t)
+
+	proof, err := BatchOpenMultiPoints(polynomials, digests, points, sha256.New(), testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range points {
+		for j := range points[i] {
+			expected := eval(polynomials[i], points[i][j])
+			if !proof.ClaimedValues[i][j].Equal(&expected) {
+				t.Fatal("wrong claimed value")
+			}
+		}
+	}
+
+	if err = BatchVerifyMultiPointsOpening(digests, &proof, points, sha256.New(), testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	// wrong claimed value
+	{
+		var wrongProof MultiPointsOpeningProof
+		wrongProof.W, wrongProof.WPrime = proof.W, proof.WPrime
+		wrongProof.ClaimedValues = make([][]fr.Element, len(proof.ClaimedValues))
+		for i := range proof.ClaimedValues {
+			wrongProof.ClaimedValues[i] = append([]fr.Element{}, proof.ClaimedValues[i]...)
+		}
+		wrongProof.ClaimedValues[3][2].Double(&wrongProof.ClaimedValues[3][2])
+		if BatchVerifyMultiPointsOpening(digests, &wrongProof, points, sha256.New(), testSRS) == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+
+	// wrong digest
+	{
+		wrongDigests := append([]Digest{}, digests...)
+		wrongDigests[0], wrongDigests[1] = wrongDigests[1], wrongDigests[0]
+		if BatchVerifyMultiPointsOpening(wrongDigests, &proof, points, sha256.New(), testSRS) == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+
+	// invalid inputs
+	if _, err = BatchOpenMultiPoints(polynomials, digests[1:], points, sha256.New(), testSRS); err != ErrInvalidNbDigests {
+		t.Fatal("expected ErrInvalidNbDigests")
+	}
+	points[4][1] = points[4][0]
+	if _, err = BatchOpenMultiPoints(polynomials, digests, points, sha256.New(), testSRS); err != ErrInvalidPoints {
+		t.Fatal("expected ErrInvalidPoints")
+	}
+	if err = BatchVerifyMultiPointsOpening(digests, &proof, points, sha256.New(), testSRS); err != ErrInvalidPoints {
+		t.Fatal("expected ErrInvalidPoints")
+	}
+}
+
+func TestSerializationMultiPointsOpeningProof(t *testing.T) {
+
+	polynomials, digests, points := multiPointsInstance(t)
+	proof, err := BatchOpenMultiPoints(polynomials, digests, points, sha256.New(), testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var _proof MultiPointsOpeningProof
+	read, err := _proof.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != read {
+		t.Fatal("number of bytes written and read differ")
+	}
+
+	if !reflect.DeepEqual(proof, _proof) {
+		t.Fatal("proof serialization failed")
+	}
+
+	// a huge number of polynomials, not followed by their claimed values, is an error
+	buf.Reset()
+	proof.ClaimedValues = nil
+	if _, err = proof.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	forged := buf.Bytes()
+	binary.BigEndian.PutUint32(forged[len(forged)-4:], math.MaxUint32)
+	if _, err = _proof.ReadFrom(bytes.NewReader(forged)); err == nil {
+		t.Fatal("reading a truncated proof should fail")
+	}
+}
+
+func BenchmarkBatchOpenMultiPoints(b *testing.B) {
+	const nbPolynomials = 10
+	polynomials := make([][]fr.Element, nbPolynomials)
+	digests := make([]Digest, nbPolynomials)
+	points := make([][]fr.Element, nbPolynomials)
+	for i := range polynomials {
+		polynomials[i] = randomPolynomial(len(testSRS.G1))
+		digests[i], _ = Commit(polynomials[i], testSRS)
+		points[i] = randomPolynomial(1 + i%3)
+	}
+	hf := sha256.New()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hf.Reset()
+		_, _ = BatchOpenMultiPoints(polynomials, digests, points, hf, testSRS)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/stretchr/testify/require"
+)
+
+// testMLE checks the operations of m against those of its dense representation
+func testMLE(t *testing.T, m MLE) {
+	assert := require.New(t)
+
+	for m.NumVars() > 0 {
+		n := m.NumVars()
+		dense := m.Dense()
+		assert.Equal(1<<n, len(dense))
+
+		for i := range dense {
+			v := m.Get(i)
+			assert.True(v.Equal(&dense[i]), "Get(%d) mismatch with %d variables", i, n)
+		}
+
+		expected, got := dense.Sum(), m.Sum()
+		assert.True(expected.Equal(&got), "Sum mismatch with %d variables", n)
+
+		coordinates := make([]fr.Element, n)
+		for i := range coordinates {
+			coordinates[i].SetRandom()
+		}
+		expected, got = dense.Evaluate(coordinates, nil), m.Evaluate(coordinates, nil)
+		assert.True(expected.Equal(&got), "Evaluate mismatch with %d variables", n)
+
+		var r fr.Element
+		r.SetRandom()
+		got = m.SumFold(r)
+		dense.Fold(r)
+		expected = dense.Sum()
+		assert.True(expected.Equal(&got), "SumFold mismatch with %d variables", n)
+
+		m.Fold(r)
+		assert.Equal(n-1, m.NumVars())
+		folded := m.Dense()
+		for i := range dense {
+			assert.True(folded[i].Equal(&dense[i]), "Fold mismatch at %d with %d variables", i, n)
+		}
+	}
+}
+
+func TestMLE(t *testing.T) {
+	const nbVars = 5
+
+	dense := make(MultiLin, 1<<nbVars)
+	for i := range dense {
+		dense[i].SetRandom()
+	}
+	q := make([]fr.Element, nbVars)
+	for i := range q {
+		q[i].SetRandom()
+	}
+	indices := []int{30, 1, 17, 16, 0, 9}
+	values := make([]fr.Element, len(indices))
+	for i := range values {
+		values[i].SetRandom()
+	}
+
+	for _, m := range []MLE{
+		&dense,
+		NewSparseMultiLin(nbVars, indices, values),
+		NewSparseMultiLin(nbVars, nil, nil),
+		NewEqMultiLin(q),
+		NewIdentityMultiLin(nbVars),
+		NewLagrangeMultiLin(nbVars, 0),
+		NewLagrangeMultiLin(nbVars, 22),
+	} {
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			testMLE(t, m)
+		})
+	}
+}
+
+func TestSparseMultiLinInvalid(t *testing.T) {
+	assert := require.New(t)
+	values := make([]fr.Element, 2)
+
+	assert.Panics(func() { NewSparseMultiLin(2, []int{1, 1}, values) }, "repeated index")
+	assert.Panics(func() { NewSparseMultiLin(2, []int{1, 4}, values) }, "index out of range")
+	assert.Panics(func() { NewSparseMultiLin(2, []int{1}, values) }, "length mismatch")
+}
+
+func TestIdentityMultiLin(t *testing.T) {
+	m := NewIdentityMultiLin(4)
+	for i := 0; i < 16; i++ {
+		var expected fr.Element
+		expected.SetUint64(uint64(i))
+		got := m.Get(i)
+		require.True(t, expected.Equal(&got))
+	}
+	var sum fr.Element
+	sum.SetUint64(15 * 16 / 2)
+	got := m.Sum()
+	require.True(t, sum.Equal(&got))
+}
+
+func BenchmarkSparseMultiLinFold(b *testing.B) {
+	const nbVars, nbEntries = 24, 1 << 10
+	indices := make([]int, nbEntries)
+	values := make([]fr.Element, nbEntries)
+	for i := range indices {
+		indices[i] = i << (nbVars - 10)
+		values[i].SetRandom()
+	}
+	var r fr.Element
+	r.SetRandom()
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		m := NewSparseMultiLin(nbVars, indices, values)
+		for m.NumVars() > 0 {
+			m.Fold(r)
+		}
+	}
+}
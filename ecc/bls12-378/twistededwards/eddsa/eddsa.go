@@ -18,6 +18,7 @@ package eddsa
 
 import (
 	"crypto/subtle"
+	"encoding/binary"
 	"errors"
 	"hash"
 	"io"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/twistededwards/scalar"
 	"github.com/consensys/gnark-crypto/signature"
 	"golang.org/x/crypto/blake2b"
 )
@@ -60,6 +62,8 @@ type Signature struct {
 }
 
 // GenerateKey generates a public and private key pair.
+//
+// The scalar multiplication computing the public key is not constant time.
 func GenerateKey(r io.Reader) (*PrivateKey, error) {
 	c := twistededwards.GetEdwardsCurve()
 
@@ -97,6 +101,19 @@ func GenerateKey(r io.Reader) (*PrivateKey, error) {
 	return &priv, nil
 }
 
+// reduceBytes returns the big-endian integer b modulo the order of the curve,
+// len(b) being a multiple of 8. Unlike scalar.Element.SetBytes, it only uses
+// constant time field operations: b is read 64 bits at a time, k ← k⋅2⁶⁴ + bᵢ.
+func reduceBytes(b []byte) scalar.Element {
+	var k, limb, twoTo64 scalar.Element
+	twoTo64.SetUint64(1 << 32).Square(&twoTo64)
+	for i := 0; i < len(b); i += 8 {
+		limb.SetUint64(binary.BigEndian.Uint64(b[i : i+8]))
+		k.Mul(&k, &twoTo64).Add(&k, &limb)
+	}
+	return k
+}
+
 // Equal compares 2 public keys
 func (pub *PublicKey) Equal(x signature.PublicKey) bool {
 	xx, ok := x.(*PublicKey)
@@ -131,9 +148,9 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 	var res Signature
 
 	// blinding factor for the private key
-	// blindingFactorBigInt must be the same size as the private key,
-	// blindingFactorBigInt = h(randomness_source||message)[:sizeFr]
-	var blindingFactorBigInt big.Int
+	// blindingFactor = h(randomness_source||message)[:sizeFr] (mod order),
+	// reduced with constant time field operations
+	var blindingFactor scalar.Element
 
 	// randSrc = privKey.randSrc || msg (-> message = MSB message .. LSB message)
 	randSrc := make([]byte, 32+len(message))
@@ -142,9 +159,12 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 
 	// randBytes = H(randSrc)
 	blindingFactorBytes := blake2b.Sum512(randSrc[:]) // TODO ensures that the hash used to build the key and the one used here is the same
-	blindingFactorBigInt.SetBytes(blindingFactorBytes[:sizeFr])
+	blindingFactor = reduceBytes(blindingFactorBytes[:sizeFr])
 
 	// compute R = randScalar*Base
+	// (this scalar multiplication is not constant time)
+	var blindingFactorBigInt big.Int
+	blindingFactor.BigInt(&blindingFactorBigInt)
 	res.R.ScalarMultiplication(&curveParams.Base, &blindingFactorBigInt)
 	if !res.R.IsOnCurve() {
 		return nil, errNotOnCurve
@@ -164,23 +184,20 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 		}
 	}
 
+	// H(R,A,M) is public, it is reduced with big.Int
 	var hramInt big.Int
 	hramBin := hFunc.Sum(nil)
 	hramInt.SetBytes(hramBin)
-
-	// Compute s = randScalarInt + H(R,A,M)*S
-	// going with big int to do ops mod curve order
-	var bscalar, bs big.Int
-	bscalar.SetBytes(privKey.scalar[:])
-	bs.Mul(&hramInt, &bscalar).
-		Add(&bs, &blindingFactorBigInt).
-		Mod(&bs, &curveParams.Order)
-	sb := bs.Bytes()
-	if len(sb) < sizeFr {
-		offset := make([]byte, sizeFr-len(sb))
-		sb = append(offset, sb...)
-	}
-	copy(res.S[:], sb[:])
+	var hram scalar.Element
+	hram.SetBigInt(&hramInt)
+
+	// Compute s = randScalar + H(R,A,M)*S (mod order)
+	// with constant time field operations on the secrets
+	s := reduceBytes(privKey.scalar[:])
+	s.Mul(&s, &hram).
+		Add(&s, &blindingFactor)
+	sBytes := s.Bytes()
+	copy(res.S[sizeFr-scalar.Bytes:], sBytes[:])
 
 	return res.Bytes(), nil
 }
@@ -0,0 +1,165 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestFFTOutOfCore(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, logSize := range []int{1, 4, 7} {
+		domain := NewDomain(1 << logSize)
+		n := int(domain.Cardinality)
+
+		pol := make(fr.Vector, n)
+		for i := range pol {
+			pol[i].SetRandom()
+		}
+		src := writeOutOfCoreFile(t, filepath.Join(dir, "src"), pol)
+		dst := createOutOfCoreFile(t, filepath.Join(dir, "dst"))
+		back := createOutOfCoreFile(t, filepath.Join(dir, "back"))
+
+		expected := make([]fr.Element, n)
+		copy(expected, pol)
+		domain.FFT(expected, DIF)
+		BitReverse(expected)
+
+		// from a single column in memory to the whole polynomial
+		columnSize := 1 << ((logSize + 1) / 2)
+		for _, maxMemory := range []int{2 * fr.Bytes * columnSize, 6 * fr.Bytes * columnSize, 2 * fr.Bytes * n} {
+			if err := domain.FFTOutOfCore(dst, src, maxMemory); err != nil {
+				t.Fatal(err)
+			}
+			evaluations := readOutOfCoreFile(t, dst, n)
+			for i := range expected {
+				if !evaluations[i].Equal(&expected[i]) {
+					t.Fatalf("size %d, memory %d: FFTOutOfCore mismatch at index %d", n, maxMemory, i)
+				}
+			}
+
+			if err := domain.FFTInverseOutOfCore(back, dst, maxMemory); err != nil {
+				t.Fatal(err)
+			}
+			coefficients := readOutOfCoreFile(t, back, n)
+			for i := range pol {
+				if !coefficients[i].Equal(&pol[i]) {
+					t.Fatalf("size %d, memory %d: FFTInverseOutOfCore(FFTOutOfCore) != id at index %d", n, maxMemory, i)
+				}
+			}
+		}
+
+		if err := domain.FFTOutOfCore(dst, src, fr.Bytes*columnSize); err == nil {
+			t.Fatal("expected an error when the memory can't hold a column")
+		}
+	}
+}
+
+func TestFFTOutOfCoreErrors(t *testing.T) {
+	dir := t.TempDir()
+	domain := NewDomain(1 << 4)
+	const maxMemory = 1 << 12
+
+	// truncated source
+	src := writeOutOfCoreFile(t, filepath.Join(dir, "src"), make(fr.Vector, 1<<3))
+	dst := createOutOfCoreFile(t, filepath.Join(dir, "dst"))
+	if err := domain.FFTOutOfCore(dst, src, maxMemory); err == nil {
+		t.Fatal("expected an error on a truncated source")
+	}
+
+	// non canonical element
+	raw := make([]byte, fr.Bytes<<4)
+	for i := range raw[:fr.Bytes] {
+		raw[i] = 0xff
+	}
+	if _, err := src.WriteAt(raw, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := domain.FFTOutOfCore(dst, src, maxMemory); err == nil {
+		t.Fatal("expected an error on a non canonical element")
+	}
+
+	// mixed-radix domain
+	if err := NewMixedRadixDomain(1<<4+1).FFTOutOfCore(dst, src, maxMemory); err == nil {
+		t.Fatal("expected an error on a mixed-radix domain")
+	}
+}
+
+func BenchmarkFFTOutOfCore(b *testing.B) {
+	const logSize = 20
+	domain := NewDomain(1 << logSize)
+	pol := make(fr.Vector, domain.Cardinality)
+	for i := range pol {
+		pol[i].SetRandom()
+	}
+	dir := b.TempDir()
+	src := writeOutOfCoreFile(b, filepath.Join(dir, "src"), pol)
+	dst := createOutOfCoreFile(b, filepath.Join(dir, "dst"))
+
+	for _, maxMemory := range []int{1 << 16, 1 << 20, 1 << 24} {
+		b.Run("memory="+strconv.Itoa(maxMemory), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				if err := domain.FFTOutOfCore(dst, src, maxMemory); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func createOutOfCoreFile(tb testing.TB, path string) *os.File {
+	f, err := os.Create(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { f.Close() })
+	return f
+}
+
+func writeOutOfCoreFile(tb testing.TB, path string, v fr.Vector) *os.File {
+	f := createOutOfCoreFile(tb, path)
+	raw := make([]byte, len(v)*fr.Bytes)
+	for i := range v {
+		fr.BigEndian.PutElement((*[fr.Bytes]byte)(raw[i*fr.Bytes:]), v[i])
+	}
+	if _, err := f.WriteAt(raw, 0); err != nil {
+		tb.Fatal(err)
+	}
+	return f
+}
+
+func readOutOfCoreFile(tb testing.TB, f *os.File, n int) fr.Vector {
+	raw := make([]byte, n*fr.Bytes)
+	if _, err := f.ReadAt(raw, 0); err != nil {
+		tb.Fatal(err)
+	}
+	v := make(fr.Vector, n)
+	for i := range v {
+		var err error
+		if v[i], err = fr.BigEndian.Element((*[fr.Bytes]byte)(raw[i*fr.Bytes:])); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return v
+}
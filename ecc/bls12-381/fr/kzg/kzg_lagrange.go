@@ -0,0 +1,221 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+)
+
+// LagrangeSRS is the SRS in Lagrange basis on a domain {1, ω, ..., ωⁿ⁻¹}: G1[i] = [Lᵢ(α)]G₁.
+// It commits to polynomials given by their evaluations on the domain, in natural order, without
+// going through an inverse FFT.
+//
+// The commitments and opening proofs are the same as those obtained in monomial basis from the SRS
+// the LagrangeSRS is derived from, which verifies them (Verify, BatchVerifySinglePoint, ...).
+type LagrangeSRS struct {
+	G1     []bls12381.G1Affine // [L₀(α)]G₁, [L₁(α)]G₁, ..., [Lₙ₋₁(α)]G₁
+	omegas []fr.Element        // 1, ω, ..., ωⁿ⁻¹
+	nInv   fr.Element          // 1/n
+}
+
+// NewLagrangeSRS returns the Lagrange basis of srs on the domain, see SRS.ToLagrange
+func NewLagrangeSRS(srs *SRS, domain *fft.Domain) (*LagrangeSRS, error) {
+	g1, err := srs.ToLagrange(domain)
+	if err != nil {
+		return nil, err
+	}
+	res := &LagrangeSRS{
+		G1:     g1,
+		omegas: make([]fr.Element, domain.Cardinality),
+		nInv:   domain.CardinalityInv,
+	}
+	res.omegas[0].SetOne()
+	for i := 1; i < len(res.omegas); i++ {
+		res.omegas[i].Mul(&res.omegas[i-1], &domain.Generator)
+	}
+	return res, nil
+}
+
+// CommitLagrange commits to the polynomial whose evaluations on the domain of srs are p, using a
+// multi exponentiation with the Lagrange basis. len(p) must be the cardinality of the domain.
+func CommitLagrange(p []fr.Element, srs *LagrangeSRS, nbTasks ...int) (Digest, error) {
+
+	if len(p) != len(srs.G1) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+
+	var res bls12381.G1Affine
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	if _, err := res.MultiExp(srs.G1, p, config); err != nil {
+		return Digest{}, err
+	}
+
+	return res, nil
+}
+
+// OpenLagrange computes an opening proof at point of the polynomial whose evaluations on the domain
+// of srs are p. The quotient (f - f(a))/(X - a) is computed in Lagrange form, so no FFT is involved.
+func OpenLagrange(p []fr.Element, point fr.Element, srs *LagrangeSRS) (OpeningProof, error) {
+	if len(p) != len(srs.G1) {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+
+	b := srs.newBarycentric(point)
+	res := OpeningProof{
+		ClaimedValue: b.eval(p),
+	}
+
+	h := b.quotient(p, res.ClaimedValue)
+	hCommit, err := CommitLagrange(h, srs)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	res.H.Set(&hCommit)
+
+	return res, nil
+}
+
+// BatchOpenSinglePointLagrange is BatchOpenSinglePoint for polynomials given by their evaluations on
+// the domain of srs. The proof is verified by BatchVerifySinglePoint.
+func BatchOpenSinglePointLagrange(polynomials [][]fr.Element, digests []Digest, point fr.Element, hf hash.Hash, srs *LagrangeSRS) (BatchOpeningProof, error) {
+
+	nbDigests := len(digests)
+	if nbDigests != len(polynomials) {
+		return BatchOpeningProof{}, ErrInvalidNbDigests
+	}
+	for _, p := range polynomials {
+		if len(p) != len(srs.G1) {
+			return BatchOpeningProof{}, ErrInvalidPolynomialSize
+		}
+	}
+
+	b := srs.newBarycentric(point)
+	res := BatchOpeningProof{
+		ClaimedValues: make([]fr.Element, nbDigests),
+	}
+	for i := range polynomials {
+		res.ClaimedValues[i] = b.eval(polynomials[i])
+	}
+
+	// derive the challenge γ, binded to the point and the commitments
+	gamma, err := deriveGamma(point, digests, hf)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	// ∑ᵢγⁱfᵢ and ∑ᵢγⁱfᵢ(a)
+	folded := make([]fr.Element, len(srs.G1))
+	copy(folded, polynomials[0])
+	foldedEvaluation := res.ClaimedValues[0]
+	acc := gamma
+	var t fr.Element
+	for i := 1; i < nbDigests; i++ {
+		for j := range folded {
+			t.Mul(&polynomials[i][j], &acc)
+			folded[j].Add(&folded[j], &t)
+		}
+		t.Mul(&res.ClaimedValues[i], &acc)
+		foldedEvaluation.Add(&foldedEvaluation, &t)
+		acc.Mul(&acc, &gamma)
+	}
+
+	h := b.quotient(folded, foldedEvaluation)
+	if res.H, err = CommitLagrange(h, srs); err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	return res, nil
+}
+
+// barycentric holds what is needed to evaluate at a and to divide by (X - a) polynomials given by
+// their evaluations on the domain
+type barycentric struct {
+	srs *LagrangeSRS
+	// inv[i] = 1/(ωⁱ - a), except at index k when a = ωᵏ lies in the domain
+	inv []fr.Element
+	k   int // -1 if a is not in the domain
+	// (aⁿ - 1)/n
+	factor fr.Element
+}
+
+func (srs *LagrangeSRS) newBarycentric(a fr.Element) barycentric {
+	n := len(srs.omegas)
+	res := barycentric{
+		srs: srs,
+		inv: make([]fr.Element, n),
+		k:   -1,
+	}
+	for i := range res.inv {
+		res.inv[i].Sub(&srs.omegas[i], &a)
+		if res.inv[i].IsZero() {
+			res.k = i
+		}
+	}
+	res.inv = fr.BatchInvert(res.inv)
+
+	var one fr.Element
+	one.SetOne()
+	res.factor.Exp(a, big.NewInt(int64(n))).
+		Sub(&res.factor, &one).
+		Mul(&res.factor, &srs.nInv)
+	return res
+}
+
+// eval returns f(a) = (aⁿ - 1)/n ∑ᵢ fᵢωⁱ/(a - ωⁱ), or fₖ if a = ωᵏ
+func (b *barycentric) eval(f []fr.Element) fr.Element {
+	if b.k >= 0 {
+		return f[b.k]
+	}
+	var res, t fr.Element
+	for i := range f {
+		t.Mul(&f[i], &b.srs.omegas[i]).Mul(&t, &b.inv[i])
+		res.Add(&res, &t)
+	}
+	res.Mul(&res, &b.factor).Neg(&res)
+	return res
+}
+
+// quotient returns the evaluations on the domain of q = (f - fa)/(X - a), that is qᵢ = (fᵢ - fa)/(ωⁱ - a).
+// If a = ωᵏ, qₖ = f'(ωᵏ) = -ω⁻ᵏ ∑_{i≠k} qᵢωⁱ.
+func (b *barycentric) quotient(f []fr.Element, fa fr.Element) []fr.Element {
+	q := make([]fr.Element, len(f))
+	for i := range q {
+		q[i].Sub(&f[i], &fa).Mul(&q[i], &b.inv[i])
+	}
+	if b.k >= 0 {
+		var t fr.Element
+		for i := range q {
+			if i != b.k {
+				t.Mul(&q[i], &b.srs.omegas[i])
+				q[b.k].Sub(&q[b.k], &t)
+			}
+		}
+		n := len(q)
+		q[b.k].Mul(&q[b.k], &b.srs.omegas[(n-b.k)%n])
+	}
+	return q
+}
@@ -0,0 +1,385 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"errors"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+)
+
+// below these sizes, the quadratic algorithms are faster than the FFT based ones
+const (
+	mulThreshold     = 64
+	divThreshold     = 64
+	subproductLeaves = 8
+)
+
+// mulDomains caches the domains of mulFFT by log₂ of their cardinality (*fft.Domain), up to
+// mulDomainsMaxLog: the twiddles of larger domains would take too much memory to be kept
+var mulDomains sync.Map
+
+const mulDomainsMaxLog = 18
+
+// mulFFTMaxLog is the log₂ of the largest FFT product; larger products are split
+// (a variable for the tests)
+var mulFFTMaxLog = int(fft.MaxOrderRoot)
+
+// ErrDuplicateAbscissae is returned by Interpolate when two interpolation points are equal
+var ErrDuplicateAbscissae = errors.New("interpolation abscissae must be distinct")
+
+// Mul sets p to p1⋅p2, of length len(p1)+len(p2)-1; large products are computed with FFTs.
+// This function allocates a new slice, p may be p1 or p2.
+func (p *Polynomial) Mul(p1, p2 Polynomial) *Polynomial {
+	if len(p1) == 0 || len(p2) == 0 {
+		*p = Polynomial{}
+		return p
+	}
+	if len(p1) < mulThreshold || len(p2) < mulThreshold {
+		*p = mulSchoolbook(p1, p2)
+	} else {
+		*p = mulFFT(p1, p2)
+	}
+	return p
+}
+
+// DivMod returns the quotient q and the remainder r of the euclidean division of p by b:
+// p = b⋅q + r with deg(r) < deg(b).
+// q has length max(len(p)-deg(b), 1) and r has length max(deg(b), 1), where deg(b) ignores the
+// leading zero coefficients of b. For large degrees, the quotient is computed from the inverse of
+// the reversed divisor, by Newton iteration.
+//
+// It panics if b is the zero polynomial.
+func (p *Polynomial) DivMod(b Polynomial) (q, r Polynomial) {
+	a := *p
+	db := len(b) - 1
+	for db >= 0 && b[db].IsZero() {
+		db--
+	}
+	if db < 0 {
+		panic("polynomial: division by zero")
+	}
+	b = b[:db+1]
+
+	if len(a) <= db {
+		r = make(Polynomial, db)
+		copy(r, a)
+		return make(Polynomial, 1), r
+	}
+
+	if len(a)-db < divThreshold || db < divThreshold {
+		q, r = divModSchoolbook(a, b)
+	} else {
+		q = divNewton(a, b)
+		var bq Polynomial
+		bq.Mul(b, q)
+		r = make(Polynomial, db)
+		for i := range r {
+			r[i].Sub(&a[i], &bq[i])
+		}
+	}
+	if len(r) == 0 {
+		r = make(Polynomial, 1)
+	}
+	return q, r
+}
+
+// Derivative returns the formal derivative of p, of length max(len(p)-1, 1)
+func (p *Polynomial) Derivative() Polynomial {
+	if len(*p) <= 1 {
+		return make(Polynomial, 1)
+	}
+	res := make(Polynomial, len(*p)-1)
+	var c fr.Element
+	for i := range res {
+		c.SetUint64(uint64(i + 1))
+		res[i].Mul(&(*p)[i+1], &c)
+	}
+	return res
+}
+
+// EvalMany returns the evaluations of p at xs. For many points and large degrees, p is reduced
+// modulo the subproduct tree of xs, in O(M(n) log(n)) with M(n) the cost of a multiplication.
+func (p *Polynomial) EvalMany(xs []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(xs))
+	if len(*p) == 0 {
+		return res
+	}
+	if len(xs) < divThreshold || len(*p) < divThreshold {
+		for i := range xs {
+			res[i] = p.Eval(&xs[i])
+		}
+		return res
+	}
+
+	tree := newSubproductTree(xs)
+	_, r := p.DivMod(tree.m)
+	tree.eval(r, res)
+	return res
+}
+
+// Interpolate returns the polynomial of length len(xs) (degree less than len(xs)) taking the
+// values ys at the distinct points xs, computed with a subproduct tree: with M = ∏ (X - xᵢ),
+//
+//	P = ∑ yᵢ/M'(xᵢ) ⋅ M/(X - xᵢ)
+//
+// the weighted sum being computed from the leaves of the tree up to its root.
+func Interpolate(xs, ys []fr.Element) (Polynomial, error) {
+	if len(xs) != len(ys) {
+		return nil, errors.New("interpolation abscissae and ordinates must have the same length")
+	}
+	if len(xs) == 0 {
+		return Polynomial{}, nil
+	}
+
+	tree := newSubproductTree(xs)
+
+	// weights yᵢ/M'(xᵢ); M'(xᵢ) = ∏_{j≠i} (xᵢ - xⱼ) is zero iff xᵢ is repeated
+	weights := make([]fr.Element, len(xs))
+	tree.eval(tree.m.Derivative(), weights)
+	for i := range weights {
+		if weights[i].IsZero() {
+			return nil, ErrDuplicateAbscissae
+		}
+	}
+	weights = fr.BatchInvert(weights)
+	for i := range weights {
+		weights[i].Mul(&weights[i], &ys[i])
+	}
+
+	return tree.combine(weights), nil
+}
+
+// subproductTree is a binary tree whose nodes are the products of the (X - xᵢ) of their leaves
+type subproductTree struct {
+	xs          []fr.Element
+	m           Polynomial // ∏ (X - xᵢ), monic of degree len(xs)
+	left, right *subproductTree
+}
+
+func newSubproductTree(xs []fr.Element) *subproductTree {
+	t := &subproductTree{xs: xs}
+	if len(xs) <= subproductLeaves {
+		t.m = make(Polynomial, len(xs)+1)
+		t.m[0].SetOne()
+		for i := range xs {
+			// m ← m⋅(X - xᵢ)
+			for j := i + 1; j > 0; j-- {
+				var tmp fr.Element
+				tmp.Mul(&t.m[j], &xs[i])
+				t.m[j].Sub(&t.m[j-1], &tmp)
+			}
+			t.m[0].Mul(&t.m[0], &xs[i]).Neg(&t.m[0])
+		}
+		return t
+	}
+	mid := len(xs) / 2
+	t.left = newSubproductTree(xs[:mid])
+	t.right = newSubproductTree(xs[mid:])
+	t.m.Mul(t.left.m, t.right.m)
+	return t
+}
+
+// eval sets res to the evaluations at t.xs of r, a polynomial of degree less than len(t.xs)
+func (t *subproductTree) eval(r Polynomial, res []fr.Element) {
+	if t.left == nil {
+		for i := range t.xs {
+			res[i] = r.Eval(&t.xs[i])
+		}
+		return
+	}
+	mid := len(t.left.xs)
+	_, r0 := r.DivMod(t.left.m)
+	_, r1 := r.DivMod(t.right.m)
+	t.left.eval(r0, res[:mid])
+	t.right.eval(r1, res[mid:])
+}
+
+// combine returns ∑ wᵢ⋅t.m/(X - xᵢ), of length len(t.xs)
+func (t *subproductTree) combine(w []fr.Element) Polynomial {
+	if t.left == nil {
+		// ∑ wᵢ⋅m/(X - xᵢ), each quotient by synthetic division
+		res := make(Polynomial, len(t.xs))
+		quotient := make(Polynomial, len(t.xs))
+		for i := range t.xs {
+			quotient[len(quotient)-1] = t.m[len(t.m)-1]
+			for j := len(quotient) - 2; j >= 0; j-- {
+				quotient[j].Mul(&quotient[j+1], &t.xs[i]).Add(&quotient[j], &t.m[j+1])
+			}
+			for j := range res {
+				var tmp fr.Element
+				tmp.Mul(&quotient[j], &w[i])
+				res[j].Add(&res[j], &tmp)
+			}
+		}
+		return res
+	}
+
+	// left⋅m_right + right⋅m_left
+	mid := len(t.left.xs)
+	var l, r Polynomial
+	l.Mul(t.left.combine(w[:mid]), t.right.m)
+	r.Mul(t.right.combine(w[mid:]), t.left.m)
+	l.Add(l, r)
+	return l[:len(t.xs)]
+}
+
+// mulSchoolbook returns p1⋅p2 in O(len(p1)⋅len(p2))
+func mulSchoolbook(p1, p2 Polynomial) Polynomial {
+	res := make(Polynomial, len(p1)+len(p2)-1)
+	var tmp fr.Element
+	for i := range p1 {
+		for j := range p2 {
+			tmp.Mul(&p1[i], &p2[j])
+			res[i+j].Add(&res[i+j], &tmp)
+		}
+	}
+	return res
+}
+
+// mulFFT returns p1⋅p2, evaluating both on a domain of size at least len(p1)+len(p2)-1
+func mulFFT(p1, p2 Polynomial) Polynomial {
+	n := len(p1) + len(p2) - 1
+	m := ecc.NextPowerOfTwo(uint64(n))
+	if bits.TrailingZeros64(m) > mulFFTMaxLog {
+		// the field has no domain large enough: split the longest operand, p1 = lo + Xᵏ⋅hi
+		if len(p1) < len(p2) {
+			p1, p2 = p2, p1
+		}
+		k := len(p1) / 2
+		var lo, hi Polynomial
+		lo.Mul(p1[:k], p2)
+		hi.Mul(p1[k:], p2)
+		res := make(Polynomial, n)
+		copy(res, lo)
+		for i := range hi {
+			res[k+i].Add(&res[k+i], &hi[i])
+		}
+		return res
+	}
+	domain := mulDomain(m)
+
+	a := make([]fr.Element, domain.Cardinality)
+	b := make([]fr.Element, domain.Cardinality)
+	copy(a, p1)
+	copy(b, p2)
+	domain.FFT(a, fft.DIF)
+	domain.FFT(b, fft.DIF)
+	for i := range a {
+		a[i].Mul(&a[i], &b[i])
+	}
+	domain.FFTInverse(a, fft.DIT)
+
+	return a[:n]
+}
+
+// mulDomain returns the domain of cardinality m (a power of 2) used by mulFFT; building it costs
+// about as much as a FFT, so it is only done once per size for the small ones
+func mulDomain(m uint64) *fft.Domain {
+	logM := bits.TrailingZeros64(m)
+	if logM > mulDomainsMaxLog {
+		return fft.NewDomain(m, fft.WithoutPrecompute(), fft.WithoutCosetTables())
+	}
+	if domain, ok := mulDomains.Load(logM); ok {
+		return domain.(*fft.Domain)
+	}
+	domain, _ := mulDomains.LoadOrStore(logM, fft.NewDomain(m, fft.WithoutPrecompute(), fft.WithoutCosetTables()))
+	return domain.(*fft.Domain)
+}
+
+// divModSchoolbook returns the quotient and remainder of a by b, whose leading coefficient is
+// non zero, by long division
+func divModSchoolbook(a, b Polynomial) (q, r Polynomial) {
+	db := len(b) - 1
+	r = a.Clone()
+	q = make(Polynomial, len(a)-db)
+
+	var lInv, tmp fr.Element
+	lInv.Inverse(&b[db])
+	for i := len(q) - 1; i >= 0; i-- {
+		q[i].Mul(&r[i+db], &lInv)
+		for j := 0; j < db; j++ {
+			tmp.Mul(&q[i], &b[j])
+			r[i+j].Sub(&r[i+j], &tmp)
+		}
+	}
+	return q, r[:db]
+}
+
+// divNewton returns the quotient of a by b, whose leading coefficient is non zero:
+// with n = len(a)-1, m = deg(b) and rev(f) = Xᵈᵉᵍ⁽ᶠ⁾⋅f(1/X), rev(q) = rev(a)⋅rev(b)⁻¹ mod Xⁿ⁻ᵐ⁺¹
+func divNewton(a, b Polynomial) Polynomial {
+	lq := len(a) - len(b) + 1
+
+	revA := make(Polynomial, lq)
+	for i := range revA {
+		revA[i] = a[len(a)-1-i]
+	}
+	revB := make(Polynomial, len(b))
+	for i := range revB {
+		revB[i] = b[len(b)-1-i]
+	}
+
+	var q Polynomial
+	q.Mul(revA, invSeries(revB, lq))
+	q = q[:lq]
+	for i, j := 0, lq-1; i < j; i, j = i+1, j-1 {
+		q[i], q[j] = q[j], q[i]
+	}
+	return q
+}
+
+// invSeries returns g of length n with f⋅g = 1 mod Xⁿ, by Newton iteration g ← g⋅(2 - f⋅g),
+// doubling the precision at each step; f[0] must be non zero
+func invSeries(f Polynomial, n int) Polynomial {
+	g := make(Polynomial, 1, n)
+	g[0].Inverse(&f[0])
+
+	var two fr.Element
+	two.SetUint64(2)
+	for k := 1; k < n; {
+		k *= 2
+		if k > n {
+			k = n
+		}
+
+		// e = 2 - f⋅g mod Xᵏ
+		var e Polynomial
+		if len(f) > k {
+			e.Mul(f[:k], g)
+		} else {
+			e.Mul(f, g)
+		}
+		if len(e) > k {
+			e = e[:k]
+		}
+		for i := range e {
+			e[i].Neg(&e[i])
+		}
+		e[0].Add(&e[0], &two)
+
+		g.Mul(g, e)
+		if len(g) > k {
+			g = g[:k]
+		}
+	}
+	return g
+}
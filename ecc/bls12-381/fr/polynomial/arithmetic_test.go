@@ -0,0 +1,188 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolynomialMul(t *testing.T) {
+	assert := require.New(t)
+
+	// sizes below and above mulThreshold
+	for _, sizes := range [][2]int{{1, 1}, {5, 7}, {100, 3}, {100, 300}, {257, 256}} {
+		p1, p2 := randomPolynomial(sizes[0]), randomPolynomial(sizes[1])
+		expected := mulSchoolbook(p1, p2)
+
+		var p Polynomial
+		p.Mul(p1, p2)
+		assert.True(p.Equal(expected), "p1⋅p2 mismatch for sizes %v", sizes)
+
+		// p may be one of the operands
+		p1.Mul(p1, p2)
+		assert.True(p1.Equal(expected), "p1⋅p2 mismatch for sizes %v when p == p1", sizes)
+	}
+
+	// the domains of the FFT products are built once per size
+	assert.Same(mulDomain(512), mulDomain(512))
+	assert.NotSame(mulDomain(512), mulDomain(1024))
+	assert.Equal(uint64(1024), mulDomain(1024).Cardinality)
+	large := uint64(1) << (mulDomainsMaxLog + 1)
+	assert.NotSame(mulDomain(large), mulDomain(large))
+
+	// products too large for the domains of the field are split
+	defer func(maxLog int) { mulFFTMaxLog = maxLog }(mulFFTMaxLog)
+	mulFFTMaxLog = 8
+	p1, p2 := randomPolynomial(300), randomPolynomial(700)
+	var p Polynomial
+	p.Mul(p1, p2)
+	assert.True(p.Equal(mulSchoolbook(p1, p2)), "split p1⋅p2 mismatch")
+}
+
+func TestPolynomialDivMod(t *testing.T) {
+	assert := require.New(t)
+
+	// sizes below and above divThreshold
+	for _, sizes := range [][2]int{{1, 1}, {10, 3}, {3, 10}, {300, 100}, {300, 2}, {1000, 333}} {
+		a, b := randomPolynomial(sizes[0]), randomPolynomial(sizes[1])
+		// the leading zero coefficients of b are ignored
+		b = append(b, make(Polynomial, 2)...)
+
+		q, r := a.DivMod(b)
+		degB := sizes[1] - 1
+		if degB > 0 {
+			assert.Equal(degB, len(r), "deg(r) < deg(b) for sizes %v", sizes)
+		}
+
+		// a = b⋅q + r
+		var bq Polynomial
+		bq.Mul(b, q).Add(bq, r)
+		for i := range bq {
+			if i < len(a) {
+				assert.True(bq[i].Equal(&a[i]), "b⋅q + r != a at %d for sizes %v", i, sizes)
+			} else {
+				assert.True(bq[i].IsZero(), "b⋅q + r != a at %d for sizes %v", i, sizes)
+			}
+		}
+	}
+
+	assert.Panics(func() {
+		a := randomPolynomial(10)
+		a.DivMod(make(Polynomial, 3))
+	})
+}
+
+func TestPolynomialInvSeries(t *testing.T) {
+	assert := require.New(t)
+
+	f := randomPolynomial(200)
+	g := invSeries(f, 150)
+
+	var fg Polynomial
+	fg.Mul(f, g)
+	assert.True(fg[0].IsOne())
+	for i := 1; i < 150; i++ {
+		assert.True(fg[i].IsZero(), "f⋅g != 1 mod X¹⁵⁰ at %d", i)
+	}
+}
+
+func TestPolynomialDerivative(t *testing.T) {
+	assert := require.New(t)
+
+	// (X³ + 2X)' = 3X² + 2
+	p := make(Polynomial, 4)
+	p[1].SetUint64(2)
+	p[3].SetOne()
+	d := p.Derivative()
+	assert.Equal(3, len(d))
+	var two, three fr.Element
+	two.SetUint64(2)
+	three.SetUint64(3)
+	assert.True(d[0].Equal(&two))
+	assert.True(d[1].IsZero())
+	assert.True(d[2].Equal(&three))
+
+	c := randomPolynomial(1)
+	d = c.Derivative()
+	assert.Equal(1, len(d))
+	assert.True(d[0].IsZero())
+}
+
+func TestPolynomialEvalMany(t *testing.T) {
+	assert := require.New(t)
+
+	for _, sizes := range [][2]int{{10, 5}, {300, 100}, {100, 300}} {
+		p := randomPolynomial(sizes[0])
+		xs := randomPolynomial(sizes[1])
+		evals := p.EvalMany(xs)
+		for i := range xs {
+			expected := p.Eval(&xs[i])
+			assert.True(evals[i].Equal(&expected), "EvalMany mismatch at %d for sizes %v", i, sizes)
+		}
+	}
+}
+
+func TestPolynomialInterpolate(t *testing.T) {
+	assert := require.New(t)
+
+	for _, n := range []int{1, 2, 7, 100, 300} {
+		xs, ys := randomPolynomial(n), randomPolynomial(n)
+		p, err := Interpolate(xs, ys)
+		assert.NoError(err)
+		assert.Equal(n, len(p))
+		for i := range xs {
+			e := p.Eval(&xs[i])
+			assert.True(e.Equal(&ys[i]), "P(xs[%d]) != ys[%d] for n = %d", i, i, n)
+		}
+	}
+
+	xs, ys := randomPolynomial(100), randomPolynomial(100)
+	xs[42] = xs[17]
+	_, err := Interpolate(xs, ys)
+	assert.Equal(ErrDuplicateAbscissae, err)
+
+	_, err = Interpolate(xs, ys[1:])
+	assert.Error(err)
+}
+
+func BenchmarkPolynomialMul(b *testing.B) {
+	p1, p2 := randomPolynomial(1<<14), randomPolynomial(1<<14)
+	var p Polynomial
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Mul(p1, p2)
+	}
+}
+
+func BenchmarkPolynomialInterpolate(b *testing.B) {
+	xs, ys := randomPolynomial(1<<10), randomPolynomial(1<<10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Interpolate(xs, ys)
+	}
+}
+
+func randomPolynomial(size int) Polynomial {
+	p := make(Polynomial, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	return p
+}
@@ -17,10 +17,14 @@
 package bls12381
 
 import (
+	"crypto"
+	_ "crypto/sha512"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/internal/fptower"
+	"github.com/consensys/gnark-crypto/field/hash"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/prop"
+	"golang.org/x/crypto/sha3"
 	"math/rand"
 	"strings"
 	"testing"
@@ -81,6 +85,50 @@ func TestHashToFpG2(t *testing.T) {
 	}
 }
 
+func TestHashToG2With(t *testing.T) {
+	dst := hashToG2Vector.dst
+	for _, c := range hashToG2Vector.cases {
+		// the default expander is expand_message_xmd with SHA-256
+		q, err := HashToG2With(hash.ExpandMsgXmd, []byte(c.msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g2TestMatchPoint(t, "P", c.msg, c.P, &q)
+
+		expanders := map[string]hash.ExpandMsg{
+			"xmd:SHA-512":  hash.ExpandMsgXmdWith(crypto.SHA512),
+			"xmd:SHA3-256": hash.ExpandMsgXmdWith(crypto.SHA3_256),
+			"xof:SHAKE128": hash.ExpandMsgXofWith(sha3.NewShake128),
+			"xof:SHAKE256": hash.ExpandMsgXofWith(sha3.NewShake256),
+		}
+		for name, expand := range expanders {
+			p, err := HashToG2With(expand, []byte(c.msg), dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !p.IsInSubGroup() {
+				t.Fatalf("%s: hash output not in subgroup", name)
+			}
+			if p.Equal(&q) {
+				t.Fatalf("%s: hash output matches the default expander's", name)
+			}
+
+			u, err := fp.HashWith(expand, []byte(c.msg), dst, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err = EncodeToG2With(expand, []byte(c.msg), dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := MapToG2(g2CoordAt(u, 0))
+			if !p.Equal(&expected) {
+				t.Fatalf("%s: encode output doesn't match the mapping of the hashed field element", name)
+			}
+		}
+	}
+}
+
 func TestMapToCurve2(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
@@ -20,11 +20,13 @@ import (
 	"errors"
 	"hash"
 	"math/big"
+	"math/bits"
 	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
 	"github.com/consensys/gnark-crypto/fiat-shamir"
 )
 
@@ -34,6 +36,8 @@ var (
 	ErrVerifyOpeningProof            = errors.New("can't verify opening proof")
 	ErrVerifyBatchOpeningSinglePoint = errors.New("can't verify batch opening proof at single point")
 	ErrMinSRSSize                    = errors.New("minimum srs size is 2")
+	ErrInvalidDomainSize             = errors.New("invalid domain size (not a power of 2 or larger than SRS)")
+	ErrInvalidPoints                 = errors.New("invalid opening points (one set of distinct points per polynomial expected)")
 )
 
 // Digest commitment of a polynomial.
@@ -90,6 +94,27 @@ func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
 	return &srs, nil
 }
 
+// ToLagrange returns the SRS in Lagrange basis on the domain: the i-th point is [Lᵢ(α)]G₁,
+// Lᵢ being the i-th Lagrange polynomial of the domain, Lᵢ(ωʲ) = 1 if i == j, 0 otherwise.
+//
+// The cardinality of the domain must be a power of 2, not larger than len(srs.G1).
+func (srs *SRS) ToLagrange(domain *fft.Domain) ([]bls24315.G1Affine, error) {
+	n := domain.Cardinality
+	if bits.OnesCount64(n) != 1 || n > uint64(len(srs.G1)) {
+		return nil, ErrInvalidDomainSize
+	}
+
+	// Lᵢ(α) = 1/n ∑ⱼ ω⁻ⁱʲ αʲ, so the Lagrange basis is the inverse FFT of [G₁, [α]G₁, ...]
+	points := make([]bls24315.G1Jac, n)
+	for i := range points {
+		points[i].FromAffine(&srs.G1[i])
+	}
+	domain.FFTInverseG1(points, fft.DIF)
+	fft.BitReverseG1(points)
+
+	return bls24315.BatchJacobianToAffineG1(points), nil
+}
+
 // OpeningProof KZG proof for opening at a single point.
 //
 // implements io.ReaderFrom and io.WriterTo
@@ -423,9 +448,7 @@ func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, points []fr
 	// combien the points and the quotients using γᵢ
 	// ∑ᵢλᵢ[p_i]([Hᵢ(α)]G₁)
 	var foldedPointsQuotients bls24315.G1Affine
-	for i := 0; i < len(randomNumbers); i++ {
-		randomNumbers[i].Mul(&randomNumbers[i], &points[i])
-	}
+	fr.Vector(randomNumbers).Mul(randomNumbers, points)
 	_, err = foldedPointsQuotients.MultiExp(quotients, randomNumbers, config)
 	if err != nil {
 		return err
@@ -467,11 +490,7 @@ func fold(di []Digest, fai []fr.Element, ci []fr.Element) (Digest, fr.Element, e
 	nbDigests := len(di)
 
 	// fold the claimed values ∑ᵢcᵢf(aᵢ)
-	var foldedEvaluations, tmp fr.Element
-	for i := 0; i < nbDigests; i++ {
-		tmp.Mul(&fai[i], &ci[i])
-		foldedEvaluations.Add(&foldedEvaluations, &tmp)
-	}
+	foldedEvaluations := fr.Vector(fai[:nbDigests]).InnerProduct(ci[:nbDigests])
 
 	// fold the digests ∑ᵢ[cᵢ]([fᵢ(α)]G₁)
 	var foldedDigests Digest
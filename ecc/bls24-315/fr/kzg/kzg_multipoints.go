@@ -0,0 +1,339 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// MultiPointsOpeningProof is a proof of the openings of polynomials fᵢ, each at its own set of
+// points Sᵢ, following Shplonk (Boneh, Drake, Fisch, Gabizon, https://eprint.iacr.org/2020/081).
+// Its size doesn't depend on the number of polynomials or points, besides the claimed values.
+//
+// implements io.ReaderFrom and io.WriterTo
+type MultiPointsOpeningProof struct {
+	// W = [h(α)]G₁, with h = ∑ᵢγⁱ(fᵢ - rᵢ)/Z_{Sᵢ}, rᵢ interpolating the claimed values of fᵢ on Sᵢ
+	// and Z_{Sᵢ} the vanishing polynomial of Sᵢ
+	W bls24315.G1Affine
+
+	// WPrime = [L(α)/(α-z)]G₁, with L = ∑ᵢγⁱZ_{T∖Sᵢ}(z)(fᵢ - rᵢ(z)) - Z_T(z)h and T = ∪ᵢSᵢ
+	WPrime bls24315.G1Affine
+
+	// ClaimedValues[i][j] purported value of fᵢ at points[i][j]
+	ClaimedValues [][]fr.Element
+}
+
+// BatchOpenMultiPoints creates an opening proof of each polynomial polynomials[i] at the points
+// points[i], made non interactive using Fiat Shamir.
+//
+// * digests is the list of committed polynomials to open, need to derive the challenges using Fiat Shamir.
+// * points[i] is the set of distinct points at which polynomials[i] is opened.
+func BatchOpenMultiPoints(polynomials [][]fr.Element, digests []Digest, points [][]fr.Element, hf hash.Hash, srs *SRS) (MultiPointsOpeningProof, error) {
+
+	if len(digests) != len(polynomials) {
+		return MultiPointsOpeningProof{}, ErrInvalidNbDigests
+	}
+	if len(points) != len(polynomials) {
+		return MultiPointsOpeningProof{}, ErrInvalidPoints
+	}
+	largestPoly := 0
+	for i, p := range polynomials {
+		if len(p) == 0 || len(p) > len(srs.G1) {
+			return MultiPointsOpeningProof{}, ErrInvalidPolynomialSize
+		}
+		if !distinctPoints(points[i]) {
+			return MultiPointsOpeningProof{}, ErrInvalidPoints
+		}
+		if len(p) > largestPoly {
+			largestPoly = len(p)
+		}
+	}
+
+	// compute the purported values
+	var res MultiPointsOpeningProof
+	res.ClaimedValues = make([][]fr.Element, len(polynomials))
+	for i := range polynomials {
+		res.ClaimedValues[i] = make([]fr.Element, len(points[i]))
+		for j := range points[i] {
+			res.ClaimedValues[i][j] = eval(polynomials[i], points[i][j])
+		}
+	}
+
+	// derive the challenge γ, binded to the points, the commitments and the claimed values
+	fs := fiatshamir.NewTranscript(hf, "gamma", "z")
+	gamma, err := deriveMultiPointsGamma(&fs, points, digests, res.ClaimedValues)
+	if err != nil {
+		return MultiPointsOpeningProof{}, err
+	}
+
+	// h = ∑ᵢγⁱ(fᵢ - rᵢ)/Z_{Sᵢ}, (fᵢ - rᵢ)/Z_{Sᵢ} being the quotient of fᵢ by Z_{Sᵢ}
+	h := make([]fr.Element, largestPoly)
+	var acc, t fr.Element
+	acc.SetOne()
+	for i := range polynomials {
+		q := divideByVanishing(polynomials[i], points[i])
+		for j := range q {
+			t.Mul(&q[j], &acc)
+			h[j].Add(&h[j], &t)
+		}
+		acc.Mul(&acc, &gamma)
+	}
+	if res.W, err = Commit(h, srs); err != nil {
+		return MultiPointsOpeningProof{}, err
+	}
+
+	// derive the challenge z, binded to W
+	if err = fs.Bind("z", res.W.Marshal()); err != nil {
+		return MultiPointsOpeningProof{}, err
+	}
+	z, err := deriveChallenge(&fs, "z")
+	if err != nil {
+		return MultiPointsOpeningProof{}, err
+	}
+
+	// L = ∑ᵢγⁱZ_{T∖Sᵢ}(z)(fᵢ - rᵢ(z)) - Z_T(z)h, which vanishes at z
+	factors, zT := multiPointsFactors(points, gamma, z)
+	l := make([]fr.Element, largestPoly)
+	for i := range polynomials {
+		for j := range polynomials[i] {
+			t.Mul(&polynomials[i][j], &factors[i])
+			l[j].Add(&l[j], &t)
+		}
+		ri := evalInterpolation(points[i], res.ClaimedValues[i], z)
+		t.Mul(&ri, &factors[i])
+		l[0].Sub(&l[0], &t)
+	}
+	for j := range h {
+		t.Mul(&h[j], &zT)
+		l[j].Sub(&l[j], &t)
+	}
+
+	// W' = [L(α)/(α-z)]G₁; a constant L is zero and so is W'
+	if len(l) > 1 {
+		lz := dividePolyByXminusA(l, fr.Element{}, z)
+		if res.WPrime, err = Commit(lz, srs); err != nil {
+			return MultiPointsOpeningProof{}, err
+		}
+	}
+
+	return res, nil
+}
+
+// BatchVerifyMultiPointsOpening verifies a proof created by BatchOpenMultiPoints, with a single pairing check.
+//
+// * digests list of digests on which the opening proof is done
+// * points[i] is the set of points at which the polynomial committed in digests[i] is opened
+func BatchVerifyMultiPointsOpening(digests []Digest, proof *MultiPointsOpeningProof, points [][]fr.Element, hf hash.Hash, srs *SRS) error {
+
+	if len(digests) != len(proof.ClaimedValues) {
+		return ErrInvalidNbDigests
+	}
+	if len(points) != len(digests) {
+		return ErrInvalidPoints
+	}
+	for i := range points {
+		if len(points[i]) != len(proof.ClaimedValues[i]) || !distinctPoints(points[i]) {
+			return ErrInvalidPoints
+		}
+	}
+
+	// derive the challenges γ and z
+	fs := fiatshamir.NewTranscript(hf, "gamma", "z")
+	gamma, err := deriveMultiPointsGamma(&fs, points, digests, proof.ClaimedValues)
+	if err != nil {
+		return err
+	}
+	if err = fs.Bind("z", proof.W.Marshal()); err != nil {
+		return err
+	}
+	z, err := deriveChallenge(&fs, "z")
+	if err != nil {
+		return err
+	}
+
+	// [L(α)]G₁ = ∑ᵢγⁱZ_{T∖Sᵢ}(z)([fᵢ(α)]G₁ - [rᵢ(z)]G₁) - Z_T(z)W
+	factors, zT := multiPointsFactors(points, gamma, z)
+	var foldedEvaluations, t fr.Element
+	for i := range points {
+		ri := evalInterpolation(points[i], proof.ClaimedValues[i], z)
+		t.Mul(&ri, &factors[i])
+		foldedEvaluations.Add(&foldedEvaluations, &t)
+	}
+
+	// the MSM also adds z⋅W' so that e([L(α) + zW']G₁, G₂) = e(W', [α]G₂)
+	bases := make([]bls24315.G1Affine, len(digests)+2)
+	copy(bases, digests)
+	bases[len(digests)] = proof.W
+	bases[len(digests)+1] = proof.WPrime
+	scalars := make([]fr.Element, len(bases))
+	copy(scalars, factors)
+	scalars[len(digests)].Neg(&zT)
+	scalars[len(digests)+1] = z
+
+	var folded bls24315.G1Affine
+	if _, err = folded.MultiExp(bases, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+
+	var foldedEvaluationsCommit bls24315.G1Affine
+	var foldedEvaluationsBigInt big.Int
+	foldedEvaluations.BigInt(&foldedEvaluationsBigInt)
+	foldedEvaluationsCommit.ScalarMultiplication(&srs.G1[0], &foldedEvaluationsBigInt)
+	folded.Sub(&folded, &foldedEvaluationsCommit)
+
+	// e([L(α) + zW']G₁, G₂).e([-W']G₁, [α]G₂) ==? 1
+	var negWPrime bls24315.G1Affine
+	negWPrime.Neg(&proof.WPrime)
+	check, err := bls24315.PairingCheck(
+		[]bls24315.G1Affine{folded, negWPrime},
+		[]bls24315.G2Affine{srs.G2[0], srs.G2[1]},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// deriveMultiPointsGamma derives the challenge γ of a multi points opening, binded to the points,
+// the commitments and the claimed values
+func deriveMultiPointsGamma(fs *fiatshamir.Transcript, points [][]fr.Element, digests []Digest, claimedValues [][]fr.Element) (fr.Element, error) {
+	for i := range digests {
+		if err := fs.Bind("gamma", digests[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+		for j := range points[i] {
+			if err := fs.Bind("gamma", points[i][j].Marshal()); err != nil {
+				return fr.Element{}, err
+			}
+			if err := fs.Bind("gamma", claimedValues[i][j].Marshal()); err != nil {
+				return fr.Element{}, err
+			}
+		}
+	}
+	return deriveChallenge(fs, "gamma")
+}
+
+func deriveChallenge(fs *fiatshamir.Transcript, name string) (fr.Element, error) {
+	b, err := fs.ComputeChallenge(name)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var res fr.Element
+	res.SetBytes(b)
+	return res, nil
+}
+
+// multiPointsFactors returns the γⁱZ_{T∖Sᵢ}(z) and Z_T(z), where T = ∪ᵢSᵢ and Z_S = ∏_{s∈S}(X - s)
+func multiPointsFactors(points [][]fr.Element, gamma, z fr.Element) ([]fr.Element, fr.Element) {
+	var zT, t fr.Element
+	zT.SetOne()
+	union := make(map[fr.Element]struct{})
+	for _, s := range points {
+		for _, p := range s {
+			if _, ok := union[p]; !ok {
+				union[p] = struct{}{}
+				t.Sub(&z, &p)
+				zT.Mul(&zT, &t)
+			}
+		}
+	}
+
+	res := make([]fr.Element, len(points))
+	var acc fr.Element
+	acc.SetOne()
+	for i, s := range points {
+		inS := make(map[fr.Element]struct{}, len(s))
+		for _, p := range s {
+			inS[p] = struct{}{}
+		}
+		res[i] = acc
+		for p := range union {
+			if _, ok := inS[p]; !ok {
+				t.Sub(&z, &p)
+				res[i].Mul(&res[i], &t)
+			}
+		}
+		acc.Mul(&acc, &gamma)
+	}
+	return res, zT
+}
+
+// divideByVanishing returns the quotient of the euclidean division of f by ∏_{s∈points}(X - s)
+func divideByVanishing(f []fr.Element, points []fr.Element) []fr.Element {
+	if len(f) <= len(points) {
+		return nil
+	}
+	q := make([]fr.Element, len(f))
+	copy(q, f)
+	for i := range points {
+		// the constant coefficient only affects the remainder, dropped at each step
+		q = dividePolyByXminusA(q, fr.Element{}, points[i])
+	}
+	return q
+}
+
+// evalInterpolation returns r(z), r being the polynomial of degree less than len(xs) such that
+// r(xs[j]) = ys[j]; the xs must be distinct
+func evalInterpolation(xs, ys []fr.Element, z fr.Element) fr.Element {
+	num := make([]fr.Element, len(xs))
+	den := make([]fr.Element, len(xs))
+	var t fr.Element
+	for j := range xs {
+		num[j].SetOne()
+		den[j].SetOne()
+		for k := range xs {
+			if k != j {
+				t.Sub(&z, &xs[k])
+				num[j].Mul(&num[j], &t)
+				t.Sub(&xs[j], &xs[k])
+				den[j].Mul(&den[j], &t)
+			}
+		}
+	}
+	den = fr.BatchInvert(den)
+
+	var res fr.Element
+	for j := range xs {
+		t.Mul(&ys[j], &num[j]).Mul(&t, &den[j])
+		res.Add(&res, &t)
+	}
+	return res
+}
+
+// distinctPoints returns true if points is not empty and has no repeated element
+func distinctPoints(points []fr.Element) bool {
+	if len(points) == 0 {
+		return false
+	}
+	seen := make(map[fr.Element]struct{}, len(points))
+	for _, p := range points {
+		if _, ok := seen[p]; ok {
+			return false
+		}
+		seen[p] = struct{}{}
+	}
+	return true
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
 )
 
 // testSRS re-used accross tests of the KZG scheme
@@ -139,6 +140,39 @@ func TestCommit(t *testing.T) {
 
 }
 
+func TestToLagrange(t *testing.T) {
+	const size = 64
+	domain := fft.NewDomain(size)
+	lagrange, err := testSRS.ToLagrange(domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// committing to the evaluations on the Lagrange basis or to the coefficients on the monomial one
+	// must give the same digest
+	evaluations := randomPolynomial(size)
+	coefficients := make([]fr.Element, size)
+	copy(coefficients, evaluations)
+	domain.FFTInverse(coefficients, fft.DIF)
+	fft.BitReverse(coefficients)
+
+	expected, err := Commit(coefficients, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var digest Digest
+	if _, err := digest.MultiExp(lagrange, evaluations, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !digest.Equal(&expected) {
+		t.Fatal("commitment in Lagrange basis doesn't match the commitment in monomial basis")
+	}
+
+	if _, err := testSRS.ToLagrange(fft.NewDomain(uint64(2 * len(testSRS.G1)))); err != ErrInvalidDomainSize {
+		t.Fatal("expected ErrInvalidDomainSize for a domain larger than the SRS")
+	}
+}
+
 func TestVerifySinglePoint(t *testing.T) {
 
 	// create a polynomial
@@ -0,0 +1,198 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolConcurrent(t *testing.T) {
+	assert := require.New(t)
+
+	pool := NewPool(16, 256)
+	pool.SetLeakTracking(true)
+
+	const nbWorkers, nbIterations = 8, 100
+	var wg sync.WaitGroup
+	wg.Add(nbWorkers)
+	for w := 0; w < nbWorkers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < nbIterations; i++ {
+				n := 1 + (w*nbIterations+i)%256
+				s := pool.Make(n)
+				for j := range s {
+					s[j].SetUint64(uint64(w))
+				}
+				c := pool.Clone(s)
+				for j := range c {
+					if !c[j].Equal(&s[j]) {
+						t.Error("clone mismatch")
+						return
+					}
+				}
+				pool.Dump(s, c)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert.NoError(pool.CheckLeaks())
+	stats := pool.Stats()
+	assert.Equal(0, stats.InUse)
+	assert.Equal(0, stats.InUseBytes)
+	assert.Equal(2, len(stats.SubPools))
+	used := 0
+	for _, s := range stats.SubPools {
+		used += s.Used
+		assert.LessOrEqual(s.GreatestNUsed, s.MaxN)
+	}
+	assert.Equal(2*nbWorkers*nbIterations, used)
+	assert.Equal(1, stats.SubPools[0].SmallestNUsed)
+	assert.Equal(256, stats.SubPools[1].GreatestNUsed)
+}
+
+func TestPoolBudget(t *testing.T) {
+	assert := require.New(t)
+
+	pool := NewPool(4, 8)
+	pool.SetMemoryBudget(8*elementSize, false)
+
+	a := pool.Make(5)
+	_, err := pool.TryMake(1)
+	assert.Equal(ErrPoolBudgetExceeded, err)
+	assert.Panics(func() { pool.Make(1) })
+
+	pool.Dump(a)
+	b, err := pool.TryMake(3)
+	assert.NoError(err)
+	c, err := pool.TryMake(4)
+	assert.NoError(err)
+	assert.Equal(8*elementSize, pool.Stats().InUseBytes)
+
+	// a blocking budget waits for memory to be dumped
+	pool.SetMemoryBudget(8*elementSize, true)
+	made := make(chan []fr.Element)
+	go func() {
+		made <- pool.Make(2)
+	}()
+	select {
+	case <-made:
+		t.Fatal("Make should wait for memory to be dumped")
+	case <-time.After(10 * time.Millisecond):
+	}
+	pool.Dump(b)
+	d := <-made
+	assert.Equal(2, len(d))
+	pool.Dump(c, d)
+
+	// a slice larger than the budget can never be made, even when blocking
+	pool.SetMemoryBudget(4*elementSize, true)
+	_, err = pool.TryMake(8)
+	assert.Equal(ErrPoolBudgetExceeded, err)
+	assert.Panics(func() { pool.Make(8) })
+
+	pool.SetMemoryBudget(0, false)
+	pool.Dump(pool.Make(8))
+	assert.NoError(pool.CheckLeaks())
+}
+
+func TestPoolBudgetChange(t *testing.T) {
+	assert := require.New(t)
+
+	pool := NewPool(4, 8)
+	pool.SetMemoryBudget(8*elementSize, true)
+	a := pool.Make(8)
+
+	// makeAsync reports the error Make panics with, nil if it succeeds
+	makeAsync := func(n int) chan error {
+		done := make(chan error)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					done <- r.(error)
+				}
+			}()
+			pool.Dump(pool.Make(n))
+			done <- nil
+		}()
+		return done
+	}
+	waiting := func(done chan error) bool {
+		select {
+		case <-done:
+			return false
+		case <-time.After(10 * time.Millisecond):
+			return true
+		}
+	}
+
+	// a blocked Make panics when the budget stops blocking
+	done := makeAsync(4)
+	assert.True(waiting(done))
+	pool.SetMemoryBudget(8*elementSize, false)
+	assert.Equal(ErrPoolBudgetExceeded, <-done)
+
+	// or becomes smaller than the slice
+	pool.SetMemoryBudget(12*elementSize, true)
+	done = makeAsync(8)
+	assert.True(waiting(done))
+	pool.SetMemoryBudget(4*elementSize, true)
+	assert.Equal(ErrPoolBudgetExceeded, <-done)
+
+	// it succeeds when the budget grows large enough, or is removed
+	pool.SetMemoryBudget(8*elementSize, true)
+	done = makeAsync(4)
+	assert.True(waiting(done))
+	pool.SetMemoryBudget(12*elementSize, true)
+	assert.NoError(<-done)
+
+	pool.SetMemoryBudget(8*elementSize, true)
+	done = makeAsync(4)
+	assert.True(waiting(done))
+	pool.SetMemoryBudget(0, true)
+	assert.NoError(<-done)
+
+	pool.Dump(a)
+	assert.NoError(pool.CheckLeaks())
+}
+
+func TestPoolLeaks(t *testing.T) {
+	assert := require.New(t)
+
+	pool := NewPool(8)
+	pool.SetLeakTracking(true)
+
+	a := pool.Make(3)
+	pool.Dump(pool.Make(3))
+	err := pool.CheckLeaks()
+	assert.Error(err)
+	assert.Contains(err.Error(), "1 slices never dumped")
+	assert.Contains(err.Error(), "TestPoolLeaks")
+
+	pool.Dump(a)
+	assert.NoError(pool.CheckLeaks())
+
+	assert.Panics(func() { pool.Dump(a) }, "dumping twice")
+	assert.Panics(func() { pool.Dump(make([]fr.Element, 3)) }, "dumping a foreign slice")
+	assert.Panics(func() { pool.Make(9) }, "no sub-pool large enough")
+}
@@ -0,0 +1,50 @@
+//go:build !purego
+// +build !purego
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fp
+
+// Add adds two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector Vector) Add(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(vector) {
+		panic("vector.Add: vectors don't have the same length")
+	}
+	if len(a) == 0 {
+		return
+	}
+	addVec(&vector[0], &a[0], &b[0], uint64(len(a)))
+}
+
+//go:noescape
+func addVec(res, a, b *Element, n uint64)
+
+// Sub subtracts two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector Vector) Sub(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(vector) {
+		panic("vector.Sub: vectors don't have the same length")
+	}
+	if len(a) == 0 {
+		return
+	}
+	subVec(&vector[0], &a[0], &b[0], uint64(len(a)))
+}
+
+//go:noescape
+func subVec(res, a, b *Element, n uint64)
@@ -0,0 +1,89 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+func TestFFTG1(t *testing.T) {
+	// the twiddles of a domain created WithoutPrecompute are computed on first use
+	for _, opts := range [][]DomainOption{nil, {WithoutPrecompute()}} {
+		for _, size := range []uint64{1, 2, 16, 64} {
+			domain := NewDomain(size, opts...)
+			g1, _, _, _ := bls24317.Generators()
+
+			// a[i] = [s[i]]G₁, so the transforms of a must be the multiples of G₁ by the transforms of s
+			s := make([]fr.Element, size)
+			a := make([]bls24317.G1Jac, size)
+			for i := range s {
+				s[i].SetRandom()
+				a[i].ScalarMultiplication(&g1, s[i].BigInt(new(big.Int)))
+			}
+			check := func(step string, expected []fr.Element, got []bls24317.G1Jac) {
+				for i := range expected {
+					var e bls24317.G1Jac
+					e.ScalarMultiplication(&g1, expected[i].BigInt(new(big.Int)))
+					if !e.Equal(&got[i]) {
+						t.Fatalf("size %d: %s mismatch at index %d", size, step, i)
+					}
+				}
+			}
+
+			domain.FFT(s, DIF)
+			domain.FFTG1(a, DIF)
+			check("FFTG1 (DIF)", s, a)
+
+			domain.FFTInverse(s, DIT)
+			domain.FFTInverseG1(a, DIT)
+			check("FFTInverseG1 (DIT)", s, a)
+
+			BitReverse(s)
+			BitReverseG1(a)
+			check("BitReverseG1", s, a)
+
+			domain.FFT(s, DIT)
+			domain.FFTG1(a, DIT)
+			check("FFTG1 (DIT)", s, a)
+
+			domain.FFTInverse(s, DIF)
+			domain.FFTInverseG1(a, DIF)
+			check("FFTInverseG1 (DIF)", s, a)
+		}
+	}
+}
+
+func BenchmarkFFTG1(b *testing.B) {
+	const size = 1 << 10
+	domain := NewDomain(size)
+	g1, _, _, _ := bls24317.Generators()
+	a := make([]bls24317.G1Jac, size)
+	for i := range a {
+		var s fr.Element
+		s.SetRandom()
+		a[i].ScalarMultiplication(&g1, s.BigInt(new(big.Int)))
+	}
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		domain.FFTG1(a, DIF)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+)
+
+func TestCommitLagrange(t *testing.T) {
+	const size = 64
+	domain := fft.NewDomain(size)
+	srs, err := NewLagrangeSRS(testSRS, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evaluations := randomPolynomial(size)
+	coefficients := make([]fr.Element, size)
+	copy(coefficients, evaluations)
+	domain.FFTInverse(coefficients, fft.DIF)
+	fft.BitReverse(coefficients)
+
+	expected, err := Commit(coefficients, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest, err := CommitLagrange(evaluations, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !digest.Equal(&expected) {
+		t.Fatal("commitment in Lagrange basis doesn't match the commitment in monomial basis")
+	}
+
+	if _, err := CommitLagrange(evaluations[1:], srs); err != ErrInvalidPolynomialSize {
+		t.Fatal("expected ErrInvalidPolynomialSize")
+	}
+}
+
+func TestOpenLagrange(t *testing.T) {
+	const size = 64
+	domain := fft.NewDomain(size)
+	srs, err := NewLagrangeSRS(testSRS, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evaluations := randomPolynomial(size)
+	coefficients := make([]fr.Element, size)
+	copy(coefficients, evaluations)
+	domain.FFTInverse(coefficients, fft.DIF)
+	fft.BitReverse(coefficients)
+
+	digest, err := CommitLagrange(evaluations, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a random point, and a point of the domain where the quotient is computed from the derivative
+	var random, inDomain fr.Element
+	random.SetRandom()
+	inDomain.Exp(domain.Generator, big.NewInt(5))
+
+	for _, point := range []fr.Element{random, inDomain} {
+		proof, err := OpenLagrange(evaluations, point, srs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// same proof as in monomial basis
+		expected, err := Open(coefficients, point, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proof.ClaimedValue.Equal(&expected.ClaimedValue) || !proof.H.Equal(&expected.H) {
+			t.Fatal("opening proof in Lagrange basis doesn't match the one in monomial basis")
+		}
+
+		if err = Verify(&digest, &proof, point, testSRS); err != nil {
+			t.Fatal(err)
+		}
+
+		proof.ClaimedValue.Double(&proof.ClaimedValue)
+		if Verify(&digest, &proof, point, testSRS) == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+}
+
+func TestBatchOpenSinglePointLagrange(t *testing.T) {
+	const size = 32
+	domain := fft.NewDomain(size)
+	srs, err := NewLagrangeSRS(testSRS, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := make([][]fr.Element, 5)
+	digests := make([]Digest, len(f))
+	for i := range f {
+		f[i] = randomPolynomial(size)
+		if digests[i], err = CommitLagrange(f[i], srs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var point fr.Element
+	point.SetRandom()
+	proof, err := BatchOpenSinglePointLagrange(f, digests, point, sha256.New(), srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	proof.ClaimedValues[2].Double(&proof.ClaimedValues[2])
+	if BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSRS) == nil {
+		t.Fatal("verifying wrong proof should have failed")
+	}
+
+	if _, err = BatchOpenSinglePointLagrange(f, digests[1:], point, sha256.New(), srs); err != ErrInvalidNbDigests {
+		t.Fatal("expected ErrInvalidNbDigests")
+	}
+}
+
+func BenchmarkOpenLagrange(b *testing.B) {
+	const size = 1 << 7
+	srs, err := NewLagrangeSRS(testSRS, fft.NewDomain(size))
+	if err != nil {
+		b.Fatal(err)
+	}
+	evaluations := randomPolynomial(size)
+	var point fr.Element
+	point.SetRandom()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = OpenLagrange(evaluations, point, srs)
+	}
+}
@@ -18,6 +18,7 @@ package kzg
 
 import (
 	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
 	"io"
 )
 
@@ -132,3 +133,56 @@ func (proof *BatchOpeningProof) ReadFrom(r io.Reader) (int64, error) {
 
 	return dec.BytesRead(), nil
 }
+
+// WriteTo writes binary encoding of a MultiPointsOpeningProof
+func (proof *MultiPointsOpeningProof) WriteTo(w io.Writer) (int64, error) {
+	enc := bls24317.NewEncoder(w)
+
+	toEncode := []interface{}{
+		&proof.W,
+		&proof.WPrime,
+		uint32(len(proof.ClaimedValues)),
+	}
+	for i := range proof.ClaimedValues {
+		toEncode = append(toEncode, proof.ClaimedValues[i])
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes MultiPointsOpeningProof data from reader.
+func (proof *MultiPointsOpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls24317.NewDecoder(r)
+
+	var nbPolynomials uint32
+	toDecode := []interface{}{
+		&proof.W,
+		&proof.WPrime,
+		&nbPolynomials,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	// nbPolynomials comes from the stream: the claimed values are appended one by one, so that
+	// a truncated or forged input can't make us allocate more than it contains
+	proof.ClaimedValues = nil
+	for i := uint32(0); i < nbPolynomials; i++ {
+		var claimedValues []fr.Element
+		if err := dec.Decode(&claimedValues); err != nil {
+			return dec.BytesRead(), err
+		}
+		proof.ClaimedValues = append(proof.ClaimedValues, claimedValues)
+	}
+
+	return dec.BytesRead(), nil
+}
@@ -0,0 +1,455 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+// MLE is a multilinear polynomial in the variables X₁, ..., Xₙ, known through its evaluations on
+// the hypercube {0,1}ⁿ, indexed as in MultiLin.
+// Besides the dense MultiLin, it is implemented by SparseMultiLin, for mostly zero evaluations, and by
+// EqMultiLin, IdentityMultiLin and LagrangeMultiLin, whose evaluations are computed on the fly from a
+// succinct description rather than stored.
+type MLE interface {
+	// NumVars returns the number n of variables
+	NumVars() int
+	// Get returns the evaluation at (b₁, b₂, ..., bₙ), of index i = ∑ᵢ 2ⁱ⁻¹ bₙ₋ᵢ
+	Get(i int) fr.Element
+	// Fold is partial evaluation function k[X₁, X₂, ..., Xₙ] → k[X₂, ..., Xₙ] by setting X₁=r
+	Fold(r fr.Element)
+	// SumFold returns ∑_{b ∈ {0,1}ⁿ⁻¹} f(r, b), the sum of the evaluations after folding at r, without folding
+	SumFold(r fr.Element) fr.Element
+	// Sum returns the sum of the evaluations on the hypercube
+	Sum() fr.Element
+	// Evaluate returns the value of the polynomial at the given coordinates
+	Evaluate(coordinates []fr.Element, p *Pool) fr.Element
+	// Dense returns the evaluations on the whole hypercube
+	Dense() MultiLin
+}
+
+var (
+	_ MLE = (*MultiLin)(nil)
+	_ MLE = (*SparseMultiLin)(nil)
+	_ MLE = (*EqMultiLin)(nil)
+	_ MLE = (*IdentityMultiLin)(nil)
+	_ MLE = (*LagrangeMultiLin)(nil)
+)
+
+// Get returns m[i]
+func (m MultiLin) Get(i int) fr.Element {
+	return m[i]
+}
+
+// SumFold returns the sum of the evaluations of m folded at r, without modifying m
+func (m MultiLin) SumFold(r fr.Element) fr.Element {
+	mid := len(m) / 2
+	s0, s1 := sumOf(m[:mid]), sumOf(m[mid:])
+	return affine(&s0, &s1, &r)
+}
+
+// Dense returns a copy of m
+func (m MultiLin) Dense() MultiLin {
+	return m.Clone()
+}
+
+// SparseMultiLin is a multilinear polynomial given by its non-zero evaluations on the hypercube.
+// With k entries, Fold, SumFold, Sum and Evaluate cost O(k⋅n) rather than O(2ⁿ).
+type SparseMultiLin struct {
+	nbVars  int
+	indices []int // strictly increasing
+	values  []fr.Element
+}
+
+// NewSparseMultiLin returns the multilinear polynomial in nbVars variables evaluating to values[j]
+// at the hypercube point of index indices[j], and to 0 elsewhere. The inputs are copied.
+// It panics if an index is out of range or repeated.
+func NewSparseMultiLin(nbVars int, indices []int, values []fr.Element) *SparseMultiLin {
+	if len(indices) != len(values) {
+		panic("indices and values must have the same length")
+	}
+	perm := make([]int, len(indices))
+	for i := range perm {
+		perm[i] = i
+	}
+	sort.Slice(perm, func(i, j int) bool { return indices[perm[i]] < indices[perm[j]] })
+
+	res := &SparseMultiLin{
+		nbVars:  nbVars,
+		indices: make([]int, len(indices)),
+		values:  make([]fr.Element, len(values)),
+	}
+	for i, j := range perm {
+		res.indices[i] = indices[j]
+		res.values[i] = values[j]
+		if indices[j] < 0 || indices[j] >= 1<<nbVars {
+			panic(fmt.Errorf("index %d out of range for %d variables", indices[j], nbVars))
+		}
+		if i > 0 && res.indices[i-1] == indices[j] {
+			panic(fmt.Errorf("repeated index %d", indices[j]))
+		}
+	}
+	return res
+}
+
+func (m *SparseMultiLin) NumVars() int {
+	return m.nbVars
+}
+
+// Entries returns the indices, in increasing order, and the values of the entries of m.
+// They must not be modified.
+func (m *SparseMultiLin) Entries() (indices []int, values []fr.Element) {
+	return m.indices, m.values
+}
+
+func (m *SparseMultiLin) Get(i int) fr.Element {
+	var res fr.Element
+	if j := sort.SearchInts(m.indices, i); j < len(m.indices) && m.indices[j] == i {
+		res = m.values[j]
+	}
+	return res
+}
+
+func (m *SparseMultiLin) Fold(r fr.Element) {
+	mid := 1 << (m.nbVars - 1)
+	s := sort.SearchInts(m.indices, mid)
+
+	var oneMinusR, t fr.Element
+	oneMinusR.SetOne()
+	oneMinusR.Sub(&oneMinusR, &r)
+
+	indices := make([]int, 0, len(m.indices))
+	values := make([]fr.Element, 0, len(m.values))
+
+	// merge the entries f(0, b) and f(1, b) into f(r, b) = f(0, b) + r(f(1, b) - f(0, b))
+	for i, j := 0, s; i < s || j < len(m.indices); {
+		switch {
+		case j == len(m.indices) || i < s && m.indices[i] < m.indices[j]-mid:
+			indices = append(indices, m.indices[i])
+			values = append(values, *t.Mul(&m.values[i], &oneMinusR))
+			i++
+		case i == s || m.indices[j]-mid < m.indices[i]:
+			indices = append(indices, m.indices[j]-mid)
+			values = append(values, *t.Mul(&m.values[j], &r))
+			j++
+		default:
+			indices = append(indices, m.indices[i])
+			values = append(values, affine(&m.values[i], &m.values[j], &r))
+			i++
+			j++
+		}
+	}
+
+	m.nbVars--
+	m.indices, m.values = indices, values
+}
+
+func (m *SparseMultiLin) SumFold(r fr.Element) fr.Element {
+	s := sort.SearchInts(m.indices, 1<<(m.nbVars-1))
+	s0, s1 := sumOf(m.values[:s]), sumOf(m.values[s:])
+	return affine(&s0, &s1, &r)
+}
+
+func (m *SparseMultiLin) Sum() fr.Element {
+	return sumOf(m.values)
+}
+
+func (m *SparseMultiLin) Evaluate(coordinates []fr.Element, _ *Pool) fr.Element {
+	if len(coordinates) != m.nbVars {
+		panic("the number of coordinates must be the number of variables")
+	}
+	oneMinus := oneMinusAll(coordinates)
+
+	var res, term fr.Element
+	res.SetZero()
+	for k, i := range m.indices {
+		term = m.values[k]
+		for j := range coordinates {
+			if i>>(m.nbVars-1-j)&1 == 1 {
+				term.Mul(&term, &coordinates[j])
+			} else {
+				term.Mul(&term, &oneMinus[j])
+			}
+		}
+		res.Add(&res, &term)
+	}
+	return res
+}
+
+func (m *SparseMultiLin) Dense() MultiLin {
+	res := make(MultiLin, 1<<m.nbVars)
+	for k, i := range m.indices {
+		res[i] = m.values[k]
+	}
+	return res
+}
+
+// EqMultiLin is the multilinear polynomial c⋅Eq(q₁, ..., qₙ, X₁, ..., Xₙ), see EvalEq
+type EqMultiLin struct {
+	q     []fr.Element
+	scale fr.Element
+}
+
+// NewEqMultiLin returns Eq(q₁, ..., qₙ, X₁, ..., Xₙ). q is copied.
+func NewEqMultiLin(q []fr.Element) *EqMultiLin {
+	res := &EqMultiLin{q: make([]fr.Element, len(q))}
+	copy(res.q, q)
+	res.scale.SetOne()
+	return res
+}
+
+func (m *EqMultiLin) NumVars() int {
+	return len(m.q)
+}
+
+func (m *EqMultiLin) Get(i int) fr.Element {
+	res := m.scale
+	for j := range m.q {
+		f := selectorFactor(i>>(len(m.q)-1-j)&1 == 1, &m.q[j])
+		res.Mul(&res, &f)
+	}
+	return res
+}
+
+func (m *EqMultiLin) Fold(r fr.Element) {
+	m.scale = m.SumFold(r)
+	m.q = m.q[1:]
+}
+
+// SumFold returns c⋅Eq(q₁, r), as Eq(q₂, ..., qₙ, ⋅) sums to 1 on the hypercube
+func (m *EqMultiLin) SumFold(r fr.Element) fr.Element {
+	res := EvalEq(m.q[:1], []fr.Element{r})
+	res.Mul(&res, &m.scale)
+	return res
+}
+
+func (m *EqMultiLin) Sum() fr.Element {
+	return m.scale
+}
+
+func (m *EqMultiLin) Evaluate(coordinates []fr.Element, _ *Pool) fr.Element {
+	if len(coordinates) != len(m.q) {
+		panic("the number of coordinates must be the number of variables")
+	}
+	res := m.scale
+	if len(m.q) != 0 {
+		eq := EvalEq(m.q, coordinates)
+		res.Mul(&res, &eq)
+	}
+	return res
+}
+
+func (m *EqMultiLin) Dense() MultiLin {
+	res := make(MultiLin, 1<<len(m.q))
+	res[0] = m.scale
+	res.Eq(m.q)
+	return res
+}
+
+// IdentityMultiLin is the multilinear polynomial c + ∑ᵢ 2ⁿ⁻ⁱ Xᵢ, evaluating to c + i at the hypercube
+// point of index i
+type IdentityMultiLin struct {
+	nbVars int
+	offset fr.Element
+}
+
+// NewIdentityMultiLin returns the multilinear polynomial evaluating to i at the hypercube point of index i
+func NewIdentityMultiLin(nbVars int) *IdentityMultiLin {
+	res := &IdentityMultiLin{nbVars: nbVars}
+	res.offset.SetZero()
+	return res
+}
+
+func (m *IdentityMultiLin) NumVars() int {
+	return m.nbVars
+}
+
+func (m *IdentityMultiLin) Get(i int) fr.Element {
+	var res fr.Element
+	res.SetUint64(uint64(i))
+	res.Add(&res, &m.offset)
+	return res
+}
+
+func (m *IdentityMultiLin) Fold(r fr.Element) {
+	m.offset = m.foldedOffset(&r)
+	m.nbVars--
+}
+
+func (m *IdentityMultiLin) SumFold(r fr.Element) fr.Element {
+	offset := m.foldedOffset(&r)
+	return identitySum(m.nbVars-1, &offset)
+}
+
+func (m *IdentityMultiLin) Sum() fr.Element {
+	return identitySum(m.nbVars, &m.offset)
+}
+
+// foldedOffset returns c + 2ⁿ⁻¹r
+func (m *IdentityMultiLin) foldedOffset(r *fr.Element) fr.Element {
+	var res fr.Element
+	res.SetUint64(1 << (m.nbVars - 1))
+	res.Mul(&res, r)
+	res.Add(&res, &m.offset)
+	return res
+}
+
+func (m *IdentityMultiLin) Evaluate(coordinates []fr.Element, _ *Pool) fr.Element {
+	if len(coordinates) != m.nbVars {
+		panic("the number of coordinates must be the number of variables")
+	}
+	// ∑ᵢ 2ⁿ⁻ⁱ Xᵢ by Horner's rule
+	var res fr.Element
+	res.SetZero()
+	for i := range coordinates {
+		res.Double(&res)
+		res.Add(&res, &coordinates[i])
+	}
+	res.Add(&res, &m.offset)
+	return res
+}
+
+func (m *IdentityMultiLin) Dense() MultiLin {
+	res := make(MultiLin, 1<<m.nbVars)
+	for i := range res {
+		res[i] = m.Get(i)
+	}
+	return res
+}
+
+// identitySum returns ∑_{0 ≤ i < N} (c + i) = N⋅c + (N/2)(N-1), with N = 2ⁿ
+func identitySum(nbVars int, c *fr.Element) fr.Element {
+	var res, t fr.Element
+	res.SetUint64(1 << nbVars)
+	res.Mul(&res, c)
+	if nbVars > 0 {
+		var nMinusOne fr.Element
+		t.SetUint64(1 << (nbVars - 1))
+		nMinusOne.SetUint64(1<<nbVars - 1)
+		t.Mul(&t, &nMinusOne)
+		res.Add(&res, &t)
+	}
+	return res
+}
+
+// LagrangeMultiLin is the multilinear polynomial c⋅Lₖ(X₁, ..., Xₙ), where the Lagrange selector Lₖ
+// evaluates to 1 at the hypercube point of index k and to 0 elsewhere
+type LagrangeMultiLin struct {
+	nbVars int
+	index  int
+	scale  fr.Element
+}
+
+// NewLagrangeMultiLin returns the Lagrange selector of the hypercube point of index k
+func NewLagrangeMultiLin(nbVars, k int) *LagrangeMultiLin {
+	if k < 0 || k >= 1<<nbVars {
+		panic(fmt.Errorf("index %d out of range for %d variables", k, nbVars))
+	}
+	res := &LagrangeMultiLin{nbVars: nbVars, index: k}
+	res.scale.SetOne()
+	return res
+}
+
+func (m *LagrangeMultiLin) NumVars() int {
+	return m.nbVars
+}
+
+func (m *LagrangeMultiLin) Get(i int) fr.Element {
+	var res fr.Element
+	if i == m.index {
+		res = m.scale
+	} else {
+		res.SetZero()
+	}
+	return res
+}
+
+func (m *LagrangeMultiLin) Fold(r fr.Element) {
+	m.scale = m.SumFold(r)
+	m.nbVars--
+	m.index &= 1<<m.nbVars - 1
+}
+
+func (m *LagrangeMultiLin) SumFold(r fr.Element) fr.Element {
+	res := selectorFactor(m.index>>(m.nbVars-1)&1 == 1, &r)
+	res.Mul(&res, &m.scale)
+	return res
+}
+
+func (m *LagrangeMultiLin) Sum() fr.Element {
+	return m.scale
+}
+
+func (m *LagrangeMultiLin) Evaluate(coordinates []fr.Element, _ *Pool) fr.Element {
+	if len(coordinates) != m.nbVars {
+		panic("the number of coordinates must be the number of variables")
+	}
+	res := m.scale
+	for j := range coordinates {
+		f := selectorFactor(m.index>>(m.nbVars-1-j)&1 == 1, &coordinates[j])
+		res.Mul(&res, &f)
+	}
+	return res
+}
+
+func (m *LagrangeMultiLin) Dense() MultiLin {
+	res := make(MultiLin, 1<<m.nbVars)
+	res[m.index] = m.scale
+	return res
+}
+
+// selectorFactor returns Eq(bit, r), that is r if bit is set and 1-r otherwise
+func selectorFactor(bit bool, r *fr.Element) fr.Element {
+	if bit {
+		return *r
+	}
+	var res fr.Element
+	res.SetOne()
+	res.Sub(&res, r)
+	return res
+}
+
+// affine returns a + r(b - a)
+func affine(a, b, r *fr.Element) fr.Element {
+	var res fr.Element
+	res.Sub(b, a)
+	res.Mul(&res, r)
+	res.Add(&res, a)
+	return res
+}
+
+func sumOf(values []fr.Element) fr.Element {
+	var res fr.Element
+	res.SetZero()
+	for i := range values {
+		res.Add(&res, &values[i])
+	}
+	return res
+}
+
+func oneMinusAll(values []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(values))
+	var one fr.Element
+	one.SetOne()
+	for i := range values {
+		res[i].Sub(&one, &values[i])
+	}
+	return res
+}
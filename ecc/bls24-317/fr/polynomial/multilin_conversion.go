@@ -0,0 +1,136 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/utils"
+)
+
+// below this size, the transforms are not parallelized
+const transformParallelThreshold = 1 << 10
+
+// MobiusTransform converts in place the evaluations of a multilinear polynomial on the hypercube,
+// indexed as in MultiLin, into its coefficients in the monomial basis: the coefficient of
+// X₁^b₁ ⋯ Xₙ^bₙ ends up at index ∑ᵢ 2ⁱ⁻¹ bₙ₋ᵢ. len(a) must be a power of 2.
+func MobiusTransform(a []fr.Element) {
+	hypercubeTransform(a, true)
+}
+
+// ZetaTransform is the inverse of MobiusTransform: it converts in place the monomial coefficients
+// of a multilinear polynomial into its evaluations on the hypercube. len(a) must be a power of 2.
+func ZetaTransform(a []fr.Element) {
+	hypercubeTransform(a, false)
+}
+
+// hypercubeTransform sets, one variable at a time, a₁ ← a₁ ∓ a₀ for all the pairs of entries of a
+// whose indices differ only in that variable, a₁ being the one with the variable set
+func hypercubeTransform(a []fr.Element, subtract bool) {
+	if bits.OnesCount(uint(len(a))) != 1 {
+		panic("length must be a power of 2")
+	}
+	half := len(a) / 2
+	for k := 1; k < len(a); k <<= 1 {
+		// j ranges over the indices with bit k unset, its bits of weight ≥ k shifted left by one
+		layer := func(start, end int) {
+			for j := start; j < end; j++ {
+				j0 := (j&^(k-1))<<1 | j&(k-1)
+				if subtract {
+					a[j0|k].Sub(&a[j0|k], &a[j0])
+				} else {
+					a[j0|k].Add(&a[j0|k], &a[j0])
+				}
+			}
+		}
+		if half < transformParallelThreshold {
+			layer(0, half)
+		} else {
+			utils.Parallelize(half, layer)
+		}
+	}
+}
+
+// Coefficients returns the coefficients of m in the monomial basis, see MobiusTransform
+func (m MultiLin) Coefficients() []fr.Element {
+	res := make([]fr.Element, len(m))
+	copy(res, m)
+	MobiusTransform(res)
+	return res
+}
+
+// NewMultiLinFromCoefficients returns the multilinear polynomial of the given coefficients in the
+// monomial basis, see MobiusTransform
+func NewMultiLinFromCoefficients(coefficients []fr.Element) MultiLin {
+	res := make(MultiLin, len(coefficients))
+	copy(res, coefficients)
+	ZetaTransform(res)
+	return res
+}
+
+// ToMultiLin returns the multilinear polynomial f̃ in n = log(len(p)) variables whose monomial
+// coefficients are those of p, so that p(x) = f̃(x^{2ⁿ⁻¹}, ..., x², x), see UnivariatePoint.
+// len(p) must be a power of 2.
+func (p Polynomial) ToMultiLin() MultiLin {
+	return NewMultiLinFromCoefficients(p)
+}
+
+// ToPolynomial is the inverse of Polynomial.ToMultiLin
+func (m MultiLin) ToPolynomial() Polynomial {
+	return m.Coefficients()
+}
+
+// UnivariatePoint returns (x^{2ⁿ⁻¹}, ..., x², x), the point at which the multilinear polynomial
+// p.ToMultiLin() evaluates to p(x)
+func UnivariatePoint(x fr.Element, nbVars int) []fr.Element {
+	res := make([]fr.Element, nbVars)
+	for i := nbVars - 1; i >= 0; i-- {
+		res[i] = x
+		x.Square(&x)
+	}
+	return res
+}
+
+// EvaluateMany returns the evaluations of m at the given points, each of m.NumVars() coordinates.
+// Each evaluation is the inner product of m with the table of Eq(point, ⋅), and m is left unchanged.
+// It panics if a point doesn't have m.NumVars() coordinates.
+func (m MultiLin) EvaluateMany(points [][]fr.Element) []fr.Element {
+	n := m.NumVars()
+	// checked before spawning the workers, so that the panic happens in the caller's goroutine
+	for i := range points {
+		if len(points[i]) != n {
+			panic("the number of coordinates must be the number of variables")
+		}
+	}
+	res := make([]fr.Element, len(points))
+	utils.Parallelize(len(points), func(start, end int) {
+		eq := make(MultiLin, len(m))
+		var t fr.Element
+		for i := start; i < end; i++ {
+			eq[0].SetOne()
+			eq.Eq(points[i])
+
+			res[i].SetZero()
+			for j := range m {
+				t.Mul(&m[j], &eq[j])
+				res[i].Add(&res[i], &t)
+			}
+		}
+	})
+	return res
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func randomMultiLin(nbVars int) MultiLin {
+	m := make(MultiLin, 1<<nbVars)
+	for i := range m {
+		m[i].SetRandom()
+	}
+	return m
+}
+
+func TestMobiusTransform(t *testing.T) {
+	assert := require.New(t)
+
+	// f = c₀ + c₁ X₂ + c₂ X₁ + c₃ X₁ X₂
+	c := randomMultiLin(2)
+	m := NewMultiLinFromCoefficients(c)
+	var expected fr.Element
+	assert.True(m[0].Equal(&c[0]))
+	expected.Add(&c[0], &c[1])
+	assert.True(m[1].Equal(&expected))
+	expected.Add(&c[0], &c[2])
+	assert.True(m[2].Equal(&expected))
+	expected.Add(&expected, &c[1]).Add(&expected, &c[3])
+	assert.True(m[3].Equal(&expected))
+
+	// round trip, above the parallelization threshold
+	for _, nbVars := range []int{0, 1, 5, 12} {
+		m := randomMultiLin(nbVars)
+		back := NewMultiLinFromCoefficients(m.Coefficients())
+		for i := range m {
+			assert.True(m[i].Equal(&back[i]), "round trip mismatch at %d with %d variables", i, nbVars)
+		}
+	}
+
+	assert.Panics(func() { MobiusTransform(make([]fr.Element, 3)) })
+}
+
+func TestPolynomialToMultiLin(t *testing.T) {
+	assert := require.New(t)
+
+	const nbVars = 6
+	p := make(Polynomial, 1<<nbVars)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	m := p.ToMultiLin()
+
+	var x fr.Element
+	x.SetRandom()
+	expected := p.Eval(&x)
+	got := m.Evaluate(UnivariatePoint(x, nbVars), nil)
+	assert.True(expected.Equal(&got))
+
+	back := m.ToPolynomial()
+	assert.True(back.Equal(p))
+}
+
+func TestMultiLinEvaluateMany(t *testing.T) {
+	assert := require.New(t)
+
+	const nbVars = 7
+	m := randomMultiLin(nbVars)
+	points := make([][]fr.Element, 20)
+	for i := range points {
+		points[i] = make([]fr.Element, nbVars)
+		for j := range points[i] {
+			points[i][j].SetRandom()
+		}
+	}
+
+	evals := m.EvaluateMany(points)
+	for i := range points {
+		expected := m.Evaluate(points[i], nil)
+		assert.True(expected.Equal(&evals[i]), "mismatch at point %d", i)
+	}
+
+	// a wrong number of coordinates panics in the caller's goroutine
+	points[len(points)-1] = points[len(points)-1][:nbVars-1]
+	assert.Panics(func() { m.EvaluateMany(points) })
+}
+
+func BenchmarkMobiusTransform(b *testing.B) {
+	m := randomMultiLin(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MobiusTransform(m)
+	}
+}
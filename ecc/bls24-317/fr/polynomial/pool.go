@@ -18,142 +18,237 @@ package polynomial
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
-	"reflect"
 	"runtime"
 	"sort"
+	"strings"
 	"sync"
-	"unsafe"
+	"sync/atomic"
 )
 
 // Memory management for polynomials
-// WARNING: This is not thread safe TODO: Make sure that is not a problem
-// TODO: There is a lot of "unsafe" memory management here and needs to be vetted thoroughly
+
+// ErrPoolBudgetExceeded is returned by TryMake when the slice would exceed the memory budget of the pool
+var ErrPoolBudgetExceeded = errors.New("polynomial pool: memory budget exceeded")
+
+// elementSize is the size in bytes of a fr.Element
+const elementSize = fr.Limbs * 8
 
 type sizedPool struct {
-	maxN  int
-	pool  sync.Pool
-	stats poolStats
+	maxN      int
+	pool      sync.Pool
+	allocated int64     // updated atomically, as the pool allocates outside of the lock
+	stats     SizeStats // protected by the lock of the parent Pool
 }
 
 type inUseData struct {
-	allocatedFor []uintptr
-	pool         *sizedPool
+	slice       *[]fr.Element
+	pool        *sizedPool
+	allocatedAt []uintptr // call stack of Make, if leak tracking is enabled
 }
 
+// Pool recycles slices of fr.Element of a few fixed capacities: Make takes a slice from the
+// sub-pool of smallest capacity fitting the requested length, and Dump gives it back.
+//
+// A Pool is safe for concurrent use. It must not be copied after its first use.
 type Pool struct {
-	//lock     sync.Mutex
-	inUse    sync.Map
-	subPools []sizedPool
-}
-
-func (p *sizedPool) get(n int) *fr.Element {
-	p.stats.maake(n)
-	return p.pool.Get().(*fr.Element)
-}
-
-func (p *sizedPool) put(ptr *fr.Element) {
-	p.stats.dump()
-	p.pool.Put(ptr)
+	lock       sync.Mutex
+	released   *sync.Cond // broadcast when memory is released, if a budget is set
+	inUse      map[*fr.Element]inUseData
+	subPools   []sizedPool
+	budget     int // maximum number of bytes in use, 0 if unlimited
+	block      bool
+	inUseBytes int
+	trackLeaks bool
 }
 
+// NewPool returns a pool of slices of capacities maxN.
+// Make panics when asked for a slice longer than the largest capacity.
 func NewPool(maxN ...int) (pool Pool) {
 
 	sort.Ints(maxN)
 	pool = Pool{
+		inUse:    make(map[*fr.Element]inUseData),
 		subPools: make([]sizedPool, len(maxN)),
 	}
 
 	for i := range pool.subPools {
 		subPool := &pool.subPools[i]
 		subPool.maxN = maxN[i]
+		subPool.stats.MaxN = maxN[i]
 		subPool.pool = sync.Pool{
 			New: func() interface{} {
-				subPool.stats.Allocated++
-				return getDataPointer(make([]fr.Element, 0, subPool.maxN))
+				atomic.AddInt64(&subPool.allocated, 1)
+				res := make([]fr.Element, subPool.maxN)
+				return &res
 			},
 		}
 	}
 	return
 }
 
+// SetMemoryBudget limits to maxBytes the total memory of the slices in use, each slice counting
+// for its capacity; maxBytes = 0 removes the limit.
+// When a slice would exceed the budget, Make waits for slices to be dumped if block is set, and
+// panics with ErrPoolBudgetExceeded otherwise. A slice larger than the budget always panics.
+// The waiting calls to Make are woken up by a new budget, and panic if it no longer lets them wait.
+func (p *Pool) SetMemoryBudget(maxBytes int, block bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.released == nil {
+		p.released = sync.NewCond(&p.lock)
+	}
+	p.budget = maxBytes
+	p.block = block
+	p.released.Broadcast()
+}
+
+// SetLeakTracking enables or disables the recording of the call stack of Make, for CheckLeaks to
+// report where the slices never dumped were made. It is meant for tests, as it slows Make down.
+func (p *Pool) SetLeakTracking(enabled bool) {
+	p.lock.Lock()
+	p.trackLeaks = enabled
+	p.lock.Unlock()
+}
+
 func (p *Pool) findCorrespondingPool(n int) *sizedPool {
 	poolI := 0
 	for poolI < len(p.subPools) && n > p.subPools[poolI].maxN {
 		poolI++
 	}
-	return &p.subPools[poolI] // out of bounds error here would mean that n is too large
+	if poolI == len(p.subPools) {
+		panic(fmt.Errorf("polynomial pool: no sub-pool for slices of length %d", n))
+	}
+	return &p.subPools[poolI]
 }
 
+// Make returns a slice of length n from the pool, to be given back with Dump.
+// Its content is not zeroed. If the pool has a blocking memory budget, Make waits for the
+// memory to be available.
 func (p *Pool) Make(n int) []fr.Element {
+	res, err := p.make(n, true)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// TryMake is like Make, but returns ErrPoolBudgetExceeded instead of waiting or panicking when
+// the slice would exceed the memory budget of the pool.
+func (p *Pool) TryMake(n int) ([]fr.Element, error) {
+	return p.make(n, false)
+}
+
+// make waits for the memory if canBlock is set and the budget is blocking.
+func (p *Pool) make(n int, canBlock bool) ([]fr.Element, error) {
 	pool := p.findCorrespondingPool(n)
-	ptr := pool.get(n)
-	p.addInUse(ptr, pool)
-	return unsafe.Slice(ptr, n)
+	size := pool.maxN * elementSize
+
+	p.lock.Lock()
+	// the budget is checked again after each wait, as SetMemoryBudget may have changed it
+	for p.budget > 0 && p.inUseBytes+size > p.budget {
+		if !canBlock || !p.block || size > p.budget {
+			p.lock.Unlock()
+			return nil, ErrPoolBudgetExceeded
+		}
+		p.released.Wait()
+	}
+	p.inUseBytes += size
+	pool.stats.make(n)
+	trackLeaks := p.trackLeaks
+	p.lock.Unlock()
+
+	data := inUseData{
+		slice: pool.pool.Get().(*[]fr.Element),
+		pool:  pool,
+	}
+	if trackLeaks {
+		pcs := make([]uintptr, 16)
+		data.allocatedAt = pcs[:runtime.Callers(3, pcs)]
+	}
+
+	res := (*data.slice)[:n]
+	p.lock.Lock()
+	p.inUse[&(*data.slice)[0]] = data
+	p.lock.Unlock()
+	return res, nil
 }
 
-// Dump dumps a set of polynomials into the pool
+// Dump gives slices made by the pool back to it. They must not be used afterwards.
 func (p *Pool) Dump(slices ...[]fr.Element) {
 	for _, slice := range slices {
-		ptr := getDataPointer(slice)
-		if metadata, ok := p.inUse.Load(ptr); ok {
-			p.inUse.Delete(ptr)
-			metadata.(inUseData).pool.put(ptr)
-		} else {
+		if cap(slice) == 0 {
 			panic("attempting to dump a slice not created by the pool")
 		}
-	}
-}
+		ptr := &slice[:1][0]
 
-func (p *Pool) addInUse(ptr *fr.Element, pool *sizedPool) {
-	pcs := make([]uintptr, 2)
-	n := runtime.Callers(3, pcs)
+		p.lock.Lock()
+		data, ok := p.inUse[ptr]
+		if !ok {
+			p.lock.Unlock()
+			panic("attempting to dump a slice not created by the pool")
+		}
+		delete(p.inUse, ptr)
+		data.pool.stats.InUse--
+		p.inUseBytes -= data.pool.maxN * elementSize
+		if p.released != nil {
+			p.released.Broadcast()
+		}
+		p.lock.Unlock()
 
-	if prevPcs, ok := p.inUse.Load(ptr); ok { // TODO: remove if unnecessary for security
-		panic(fmt.Errorf("re-allocated non-dumped slice, previously allocated at %v", runtime.CallersFrames(prevPcs.(inUseData).allocatedFor)))
+		data.pool.pool.Put(data.slice)
 	}
-	p.inUse.Store(ptr, inUseData{
-		allocatedFor: pcs[:n],
-		pool:         pool,
-	})
 }
 
-func printFrame(frame runtime.Frame) {
-	fmt.Printf("\t%s line %d, function %s\n", frame.File, frame.Line, frame.Function)
-}
+// CheckLeaks returns an error if some slices made by the pool were never dumped, with the call
+// stacks that made them if leak tracking is enabled. It is meant to be called at the end of tests.
+func (p *Pool) CheckLeaks() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
 
-func (p *Pool) printInUse() {
-	fmt.Println("slices never dumped allocated at:")
-	p.inUse.Range(func(_, pcs any) bool {
-		fmt.Println("-------------------------")
+	if len(p.inUse) == 0 {
+		return nil
+	}
 
-		var frame runtime.Frame
-		frames := runtime.CallersFrames(pcs.(inUseData).allocatedFor)
-		more := true
-		for more {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "polynomial pool: %d slices never dumped", len(p.inUse))
+	for _, data := range p.inUse {
+		if len(data.allocatedAt) == 0 {
+			continue
+		}
+		sb.WriteString("\n-------------------------\nallocated at:")
+		frames := runtime.CallersFrames(data.allocatedAt)
+		for more := true; more; {
+			var frame runtime.Frame
 			frame, more = frames.Next()
-			printFrame(frame)
+			fmt.Fprintf(&sb, "\n\t%s line %d, function %s", frame.File, frame.Line, frame.Function)
 		}
-		return true
-	})
+	}
+	return errors.New(sb.String())
 }
 
-type poolStats struct {
-	Used          int
-	Allocated     int
-	ReuseRate     float64
-	InUse         int
-	GreatestNUsed int
-	SmallestNUsed int
+// SizeStats are the usage statistics of the slices of a given capacity
+type SizeStats struct {
+	MaxN          int     // capacity of the slices
+	Used          int     // number of slices made
+	Allocated     int     // number of slices allocated, the others being reused
+	ReuseRate     float64 // Used / Allocated
+	InUse         int     // number of slices made and not dumped yet
+	GreatestNUsed int     // greatest length requested
+	SmallestNUsed int     // smallest length requested
 }
 
-type poolsStats struct {
-	SubPools []poolStats
-	InUse    int
+// PoolStats are the usage statistics of a Pool
+type PoolStats struct {
+	SubPools   []SizeStats // by increasing capacity
+	InUse      int         // number of slices made and not dumped yet
+	InUseBytes int         // memory of the slices in use, counted at their capacity
+	Budget     int         // memory budget in bytes, 0 if unlimited
 }
 
-func (s *poolStats) maake(n int) {
+func (s *SizeStats) make(n int) {
 	s.Used++
 	s.InUse++
 	if n > s.GreatestNUsed {
@@ -164,38 +259,38 @@ func (s *poolStats) maake(n int) {
 	}
 }
 
-func (s *poolStats) dump() {
-	s.InUse--
-}
-
-func (s *poolStats) finalize() {
-	s.ReuseRate = float64(s.Used) / float64(s.Allocated)
-}
-
-func getDataPointer(slice []fr.Element) *fr.Element {
-	header := (*reflect.SliceHeader)(unsafe.Pointer(&slice))
-	return (*fr.Element)(unsafe.Pointer(header.Data))
-}
+// Stats returns a snapshot of the usage statistics of the pool
+func (p *Pool) Stats() PoolStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
 
-func (p *Pool) PrintPoolStats() {
-	InUse := 0
-	subStats := make([]poolStats, len(p.subPools))
+	res := PoolStats{
+		SubPools:   make([]SizeStats, len(p.subPools)),
+		InUseBytes: p.inUseBytes,
+		Budget:     p.budget,
+	}
 	for i := range p.subPools {
-		subPool := &p.subPools[i]
-		subPool.stats.finalize()
-		subStats[i] = subPool.stats
-		InUse += subPool.stats.InUse
+		s := p.subPools[i].stats
+		s.Allocated = int(atomic.LoadInt64(&p.subPools[i].allocated))
+		if s.Allocated != 0 {
+			s.ReuseRate = float64(s.Used) / float64(s.Allocated)
+		}
+		res.SubPools[i] = s
+		res.InUse += s.InUse
 	}
+	return res
+}
 
-	poolsStats := poolsStats{
-		SubPools: subStats,
-		InUse:    InUse,
-	}
-	serialized, _ := json.MarshalIndent(poolsStats, "", "  ")
+// PrintPoolStats prints the usage statistics of the pool and the slices never dumped to stdout
+func (p *Pool) PrintPoolStats() {
+	serialized, _ := json.MarshalIndent(p.Stats(), "", "  ")
 	fmt.Println(string(serialized))
-	p.printInUse()
+	if err := p.CheckLeaks(); err != nil {
+		fmt.Println(err)
+	}
 }
 
+// Clone returns a copy of slice made by the pool
 func (p *Pool) Clone(slice []fr.Element) []fr.Element {
 	res := p.Make(len(slice))
 	copy(res, slice)
@@ -16,6 +16,10 @@
 
 // Package eddsa provides EdDSA signature scheme on bls24-317's twisted edwards curve.
 //
+// The field operations on the secret scalar and on the blinding factor are
+// constant time, but the scalar multiplications by them (in GenerateKey and
+// Sign) are not.
+//
 // # See also
 //
 // https://en.wikipedia.org/wiki/EdDSA
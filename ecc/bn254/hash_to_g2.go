@@ -19,6 +19,7 @@ package bn254
 import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/consensys/gnark-crypto/ecc/bn254/internal/fptower"
+	"github.com/consensys/gnark-crypto/field/hash"
 )
 
 // mapToCurve2 implements the Shallue and van de Woestijne method, applicable to any elliptic curve in Weierstrass form
@@ -153,9 +154,15 @@ func MapToG2(u fptower.E2) G2Affine {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func EncodeToG2(msg, dst []byte) (G2Affine, error) {
+	return EncodeToG2With(hash.ExpandMsgXmd, msg, dst)
+}
+
+// EncodeToG2With is EncodeToG2 with expand in place of expand_message_xmd with SHA-256,
+// to match suites built on other hash functions (see hash.ExpandMsgXmdWith and hash.ExpandMsgXofWith).
+func EncodeToG2With(expand hash.ExpandMsg, msg, dst []byte) (G2Affine, error) {
 
 	var res G2Affine
-	u, err := fp.Hash(msg, dst, 2)
+	u, err := fp.HashWith(expand, msg, dst, 2)
 	if err != nil {
 		return res, err
 	}
@@ -174,7 +181,13 @@ func EncodeToG2(msg, dst []byte) (G2Affine, error) {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func HashToG2(msg, dst []byte) (G2Affine, error) {
-	u, err := fp.Hash(msg, dst, 2*2)
+	return HashToG2With(hash.ExpandMsgXmd, msg, dst)
+}
+
+// HashToG2With is HashToG2 with expand in place of expand_message_xmd with SHA-256,
+// to match suites built on other hash functions (see hash.ExpandMsgXmdWith and hash.ExpandMsgXofWith).
+func HashToG2With(expand hash.ExpandMsg, msg, dst []byte) (G2Affine, error) {
+	u, err := fp.HashWith(expand, msg, dst, 2*2)
 	if err != nil {
 		return G2Affine{}, err
 	}
@@ -25,4 +25,8 @@
 // - Wikipedia: https://en.wikipedia.org/wiki/Elliptic_Curve_Digital_Signature_Algorithm
 // - FIPS 186-4: https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.186-4.pdf
 // - SEC 1, v-2: https://www.secg.org/sec1-v2.pdf
+//
+// The field operations on the secret scalar and on the nonce are constant
+// time, but the scalar multiplications by them (in GenerateKey and Sign) are
+// not.
 package ecdsa
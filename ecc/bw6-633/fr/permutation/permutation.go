@@ -76,7 +76,7 @@ func evaluateAccumulationPolynomialBitReversed(lt1, lt2 []fr.Element, epsilon fr
 		z[_ii].Mul(&z[_i], t.Sub(&epsilon, &lt1[i]))
 		d[i+1].Mul(&d[i], t.Sub(&epsilon, &lt2[i]))
 	}
-	d = fr.BatchInvert(d)
+	fr.BatchInvertInPlace(d)
 	for i := 0; i < s-1; i++ {
 		_ii := int(bits.Reverse64(uint64((i+1)%s)) >> nn)
 		z[_ii].Mul(&z[_ii], &d[i+1])
@@ -118,7 +118,7 @@ func evaluateSecondPartNumReverse(lz []fr.Element, d *fft.Domain) []fr.Element {
 		u[i].Sub(&g, &o)
 		g.Mul(&g, &d.Generator)
 	}
-	u = fr.BatchInvert(u)
+	fr.BatchInvertInPlace(u)
 	res := make([]fr.Element, s)
 	nn := uint64(64 - bits.TrailingZeros64(uint64(s)))
 	for i := 0; i < s; i++ {
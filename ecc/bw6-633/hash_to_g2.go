@@ -18,6 +18,7 @@ package bw6633
 
 import (
 	"github.com/consensys/gnark-crypto/ecc/bw6-633/fp"
+	"github.com/consensys/gnark-crypto/field/hash"
 
 	"math/big"
 )
@@ -257,9 +258,15 @@ func MapToG2(u fp.Element) G2Affine {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func EncodeToG2(msg, dst []byte) (G2Affine, error) {
+	return EncodeToG2With(hash.ExpandMsgXmd, msg, dst)
+}
+
+// EncodeToG2With is EncodeToG2 with expand in place of expand_message_xmd with SHA-256,
+// to match suites built on other hash functions (see hash.ExpandMsgXmdWith and hash.ExpandMsgXofWith).
+func EncodeToG2With(expand hash.ExpandMsg, msg, dst []byte) (G2Affine, error) {
 
 	var res G2Affine
-	u, err := fp.Hash(msg, dst, 1)
+	u, err := fp.HashWith(expand, msg, dst, 1)
 	if err != nil {
 		return res, err
 	}
@@ -277,7 +284,13 @@ func EncodeToG2(msg, dst []byte) (G2Affine, error) {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func HashToG2(msg, dst []byte) (G2Affine, error) {
-	u, err := fp.Hash(msg, dst, 2*1)
+	return HashToG2With(hash.ExpandMsgXmd, msg, dst)
+}
+
+// HashToG2With is HashToG2 with expand in place of expand_message_xmd with SHA-256,
+// to match suites built on other hash functions (see hash.ExpandMsgXmdWith and hash.ExpandMsgXofWith).
+func HashToG2With(expand hash.ExpandMsg, msg, dst []byte) (G2Affine, error) {
+	u, err := fp.HashWith(expand, msg, dst, 2*1)
 	if err != nil {
 		return G2Affine{}, err
 	}
@@ -85,7 +85,7 @@ func evaluateXnMinusOneDomainBigCoset(domains [2]*fft.Domain) []fr.Element {
 	}
 	res[len(res)-1].Sub(&res[len(res)-1], &one)
 
-	res = fr.BatchInvert(res)
+	fr.BatchInvertInPlace(res)
 
 	return res
 }
@@ -82,7 +82,7 @@ func evaluateAccumulationPolynomial(lf, lt, lh1, lh2 []fr.Element, beta, gamma f
 
 		d[i].Mul(&d[i], &u)
 	}
-	d = fr.BatchInvert(d)
+	fr.BatchInvertInPlace(d)
 
 	z[0].SetOne()
 	var a, b, e fr.Element
@@ -209,7 +209,7 @@ func evaluateL0DomainBig(domainBig *fft.Domain) ([2]fr.Element, []fr.Element) {
 		denL0[i].Sub(&acc, &one)
 		acc.Mul(&acc, &domainBig.Generator)
 	}
-	denL0 = fr.BatchInvert(denL0)
+	fr.BatchInvertInPlace(denL0)
 
 	return xnMinusOne, denL0
 }
@@ -235,7 +235,7 @@ func evaluationLnDomainBig(domainBig *fft.Domain) ([2]fr.Element, []fr.Element)
 		denLn[i].Sub(&acc, &gg)
 		acc.Mul(&acc, &domainBig.Generator)
 	}
-	denLn = fr.BatchInvert(denLn)
+	fr.BatchInvertInPlace(denLn)
 
 	return numLn, denLn
 
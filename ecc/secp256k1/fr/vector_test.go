@@ -17,7 +17,10 @@
 package fr
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/stretchr/testify/require"
+	"math/rand"
 	"reflect"
 	"sort"
 	"testing"
@@ -70,3 +73,141 @@ func TestVectorEmptyRoundTrip(t *testing.T) {
 
 	assert.True(reflect.DeepEqual(v1, v2))
 }
+
+func TestVectorOps(t *testing.T) {
+	assert := require.New(t)
+
+	for _, size := range []int{0, 1, 2, 3, 15, 16, 17, 257} {
+		a, b := randomVector(size), randomVector(size)
+		var c Element
+		c.SetRandom()
+
+		res := make(Vector, size)
+		var expected Element
+
+		res.Add(a, b)
+		for i := 0; i < size; i++ {
+			expected.Add(&a[i], &b[i])
+			assert.True(res[i].Equal(&expected), "Add mismatch at index %d (size %d)", i, size)
+		}
+
+		res.Sub(a, b)
+		for i := 0; i < size; i++ {
+			expected.Sub(&a[i], &b[i])
+			assert.True(res[i].Equal(&expected), "Sub mismatch at index %d (size %d)", i, size)
+		}
+
+		res.ScalarMul(a, &c)
+		for i := 0; i < size; i++ {
+			expected.Mul(&a[i], &c)
+			assert.True(res[i].Equal(&expected), "ScalarMul mismatch at index %d (size %d)", i, size)
+		}
+
+		res.Mul(a, b)
+		for i := 0; i < size; i++ {
+			expected.Mul(&a[i], &b[i])
+			assert.True(res[i].Equal(&expected), "Mul mismatch at index %d (size %d)", i, size)
+		}
+
+		var sum, innerProduct, tmp Element
+		for i := 0; i < size; i++ {
+			sum.Add(&sum, &a[i])
+			tmp.Mul(&a[i], &b[i])
+			innerProduct.Add(&innerProduct, &tmp)
+		}
+		s := a.Sum()
+		assert.True(s.Equal(&sum), "Sum mismatch (size %d)", size)
+		ip := a.InnerProduct(b)
+		assert.True(ip.Equal(&innerProduct), "InnerProduct mismatch (size %d)", size)
+
+		// in place operations
+		copy(res, a)
+		res.Add(res, b)
+		res.Sub(res, b)
+		assert.True(reflect.DeepEqual(res, a), "Add then Sub in place should be identity (size %d)", size)
+	}
+
+	assert.Panics(func() {
+		make(Vector, 2).Add(make(Vector, 2), make(Vector, 3))
+	})
+	assert.Panics(func() {
+		make(Vector, 2).Sub(make(Vector, 3), make(Vector, 3))
+	})
+	assert.Panics(func() {
+		make(Vector, 2).InnerProduct(make(Vector, 3))
+	})
+}
+
+func BenchmarkVectorOps(b *testing.B) {
+	const N = 1 << 16
+	a1, b1 := randomVector(N), randomVector(N)
+	res := make(Vector, N)
+	var c Element
+	c.SetRandom()
+
+	for _, n := range []int{1 << 8, 1 << 12, N} {
+		b.Run(fmt.Sprintf("add/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				res[:n].Add(a1[:n], b1[:n])
+			}
+		})
+		b.Run(fmt.Sprintf("sub/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				res[:n].Sub(a1[:n], b1[:n])
+			}
+		})
+		b.Run(fmt.Sprintf("scalarMul/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				res[:n].ScalarMul(a1[:n], &c)
+			}
+		})
+		b.Run(fmt.Sprintf("mul/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				res[:n].Mul(a1[:n], b1[:n])
+			}
+		})
+		b.Run(fmt.Sprintf("sum/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = a1[:n].Sum()
+			}
+		})
+		b.Run(fmt.Sprintf("innerProduct/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = a1[:n].InnerProduct(b1[:n])
+			}
+		})
+	}
+}
+
+func TestVectorRandom(t *testing.T) {
+	assert := require.New(t)
+
+	// same seed, same vector
+	v1, err := Random(rand.New(rand.NewSource(42)), 17)
+	assert.NoError(err)
+	v2, err := Random(rand.New(rand.NewSource(42)), 17)
+	assert.NoError(err)
+	assert.True(reflect.DeepEqual(v1, v2))
+	for i := range v1 {
+		assert.True(v1[i].smallerThanModulus(), "element %d is not reduced", i)
+	}
+
+	v3, err := Random(rand.New(rand.NewSource(43)), 17)
+	assert.NoError(err)
+	assert.False(reflect.DeepEqual(v1, v3))
+
+	// reader errors are reported
+	_, err = Random(bytes.NewReader(nil), 1)
+	assert.Error(err)
+	empty, err := Random(bytes.NewReader(nil), 0)
+	assert.NoError(err)
+	assert.Equal(0, len(empty))
+}
+
+func randomVector(size int) Vector {
+	v := make(Vector, size)
+	for i := 0; i < size; i++ {
+		v[i].SetRandom()
+	}
+	return v
+}
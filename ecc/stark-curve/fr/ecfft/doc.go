@@ -0,0 +1,26 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ecfft provides the elliptic curve fast Fourier transform (ECFFT) over a field without a
+// large 2-adic subgroup.
+//
+// The evaluation domains are the x-coordinates of a coset of a subgroup of order 2ᵏ of an elliptic
+// curve; a chain of 2-isogenies plays the role of the squaring map of the classic FFT. Polynomials of
+// degree less than n are evaluated on (Enter) and interpolated from (Exit) a domain of size n in
+// O(n log² n) operations.
+//
+// See Ben-Sasson, Carmon, Kopparty, Levit, "Elliptic Curve Fast Fourier Transform (ECFFT) Part I".
+package ecfft
@@ -0,0 +1,353 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecfft
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fr"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// maxLogCardinality is the log₂ of the cardinality of the largest domain, the order of the curve point G
+const maxLogCardinality = 18
+
+// Domain is a set of Cardinality (a power of 2) points on which the polynomials of degree less than
+// Cardinality are evaluated (Enter) and interpolated (Exit).
+//
+// The points are the x-coordinates of a coset R + <G> of a subgroup of order Cardinality of a curve E₀.
+// The 2-isogenies E₀ → E₁ → … whose kernels are in <G> map this set 2-to-1 onto the x-coordinates
+// of the cosets R₁ + <G₁>, R₂ + <G₂>, … of the next curves of the chain.
+type Domain struct {
+	Cardinality uint64
+
+	// Points of the domain, ordered so that for every power of 2 m < Cardinality, Points[:m] and
+	// Points[m:2m] are the two halves of a domain of size 2m (those of Extend when m = Cardinality/2)
+	Points []fr.Element
+
+	// levels[c] is the domain on the c-th curve of the isogeny chain, and the tables of Extend on it
+	levels []level
+
+	// powers[j][i] = Points[i]^(2ʲ), for i < 2ʲ⁺¹
+	powers [][]fr.Element
+
+	// for m = 2ʲ, with Z the vanishing polynomial of Points[:m]:
+	// zInv[j] are the inverses of Z on Points[m:2m], and q[j] the evaluations of Z² div Xᵐ on Points[:2m]
+	zInv, q [][]fr.Element
+}
+
+// level is the domain on a curve of the isogeny chain, whose x-map is ψ(x) = x + t/(x - x₀).
+// ψ(points[2i]) = ψ(points[2i+1]) is the i-th point of the next level.
+type level struct {
+	points []fr.Element
+	x0, t  fr.Element
+
+	// pairInv[i] = 1/(points[2i] - points[2i+1])
+	pairInv []fr.Element
+
+	// for m = 2ʲ ≥ 2, vPow[j][i] = (points[i] - x₀)^(m/2 - 1) and vInvPow[j][i] its inverse, for i < 2m
+	vPow, vInvPow [][]fr.Element
+}
+
+// NewDomain returns a domain of cardinality the smallest power of 2 ≥ m.
+//
+// It panics if m is larger than 2^maxLogCardinality.
+func NewDomain(m uint64) *Domain {
+	n := ecc.NextPowerOfTwo(m)
+	logN := bits.TrailingZeros64(n)
+	if logN > maxLogCardinality {
+		panic(fmt.Sprintf("m (%d) is too big: the largest ecfft domain has %d points", m, uint64(1)<<maxLogCardinality))
+	}
+
+	d := &Domain{Cardinality: n}
+	a, b, g, r := curveParameters()
+	for i := logN; i < maxLogCardinality; i++ {
+		g = g.double(&a)
+	}
+	// g has order n
+	d.Points = cosetAbscissae(r, g, n, &a)
+	bitReverse(d.Points)
+
+	d.computeLevels(a, b, g.x)
+	d.computePowers()
+	d.computeExitTables()
+
+	return d
+}
+
+// computeLevels computes the domains on the curves of the isogeny chain, E₀: y² = x³ + a⋅x + b being
+// the curve of the domain and gx the abscissa of the generator of its subgroup
+func (d *Domain) computeLevels(a, b, gx fr.Element) {
+	logN := bits.TrailingZeros64(d.Cardinality)
+	points := d.Points
+
+	// Extend on a level of 2 points is the identity
+	for c := 0; c+1 < logN; c++ {
+		logNc := logN - c
+		l := level{points: points}
+
+		// the kernel of the isogeny is generated by [n_c/2]G_c
+		l.x0 = gx
+		for i := 1; i < logNc; i++ {
+			l.x0 = xDouble(&l.x0, &a, &b)
+		}
+		l.t.Square(&l.x0).Mul(&l.t, &three).Add(&l.t, &a)
+
+		v := make([]fr.Element, len(points))
+		diff := make([]fr.Element, len(points)/2)
+		for i := range points {
+			v[i].Sub(&points[i], &l.x0)
+		}
+		for i := range diff {
+			diff[i].Sub(&points[2*i], &points[2*i+1])
+		}
+		vInv := fr.BatchInvert(v)
+		l.pairInv = fr.BatchInvert(diff)
+
+		l.vPow = make([][]fr.Element, logNc)
+		l.vInvPow = make([][]fr.Element, logNc)
+		for j := 1; j < logNc; j++ {
+			l.vPow[j] = make([]fr.Element, 2<<j)
+			l.vInvPow[j] = make([]fr.Element, 2<<j)
+		}
+		for i := range points {
+			var pw, pwInv fr.Element
+			pw.SetOne()
+			pwInv.SetOne()
+			for j := 1; j < logNc; j++ {
+				if j > 1 {
+					// m/2 - 1 = 2(m/4 - 1) + 1
+					pw.Square(&pw).Mul(&pw, &v[i])
+					pwInv.Square(&pwInv).Mul(&pwInv, &vInv[i])
+				}
+				if i < 2<<j {
+					l.vPow[j][i] = pw
+					l.vInvPow[j][i] = pwInv
+				}
+			}
+		}
+
+		// next curve, by Vélu's formulas
+		next := make([]fr.Element, len(points)/2)
+		for i := range next {
+			next[i].Mul(&l.t, &vInv[2*i]).Add(&next[i], &points[2*i])
+		}
+		gx = l.psi(&gx)
+		var t5, t7 fr.Element
+		t5.SetUint64(5).Mul(&t5, &l.t)
+		t7.SetUint64(7).Mul(&t7, &l.t).Mul(&t7, &l.x0)
+		a.Sub(&a, &t5)
+		b.Sub(&b, &t7)
+
+		d.levels = append(d.levels, l)
+		points = next
+	}
+}
+
+// psi returns ψ(x) = x + t/(x - x₀)
+func (l *level) psi(x *fr.Element) fr.Element {
+	var res fr.Element
+	res.Sub(x, &l.x0).Inverse(&res).Mul(&res, &l.t).Add(&res, x)
+	return res
+}
+
+// computePowers computes the powers of the points used by Enter and Exit
+func (d *Domain) computePowers() {
+	logN := bits.TrailingZeros64(d.Cardinality)
+	d.powers = make([][]fr.Element, logN)
+	for j := range d.powers {
+		d.powers[j] = make([]fr.Element, 2<<j)
+	}
+	for i := range d.Points {
+		x := d.Points[i]
+		for j := range d.powers {
+			if i < 2<<j {
+				d.powers[j][i] = x
+			}
+			x.Square(&x)
+		}
+	}
+}
+
+// computeExitTables computes zInv and q for increasing sizes, using Enter and Exit on the smaller ones.
+//
+// With Z = Xᵐ + Y the vanishing polynomial of Points[:m], Y = -Xᵐ on Points[:m] gives Y, hence Z on
+// Points[m:2m] by Extend, and Z² div Xᵐ = Xᵐ + 2Y + (Y² div Xᵐ), where with Y = Y₀ + X^(m/2)⋅Y₁,
+// Y² div Xᵐ = Y₁² + 2⋅(Y₀⋅Y₁ div X^(m/2)) involves products of degree less than m only.
+func (d *Domain) computeExitTables() {
+	logN := bits.TrailingZeros64(d.Cardinality)
+	d.zInv = make([][]fr.Element, logN)
+	d.q = make([][]fr.Element, logN)
+	if logN == 0 {
+		return
+	}
+	scratch := make([]fr.Element, 4*d.Cardinality)
+
+	// m = 1: Z = X - Points[0] and Z² div X = X - 2⋅Points[0]
+	var z, twice fr.Element
+	z.Sub(&d.Points[1], &d.Points[0])
+	d.zInv[0] = []fr.Element{*z.Inverse(&z)}
+	twice.Double(&d.Points[0])
+	d.q[0] = make([]fr.Element, 2)
+	d.q[0][0].Neg(&d.Points[0])
+	d.q[0][1].Sub(&d.Points[1], &twice)
+
+	for j := 1; j < logN; j++ {
+		m := 1 << j
+		h := m / 2
+
+		y := make([]fr.Element, m)
+		for i := range y {
+			y[i].Neg(&d.powers[j][i])
+		}
+		zS := make([]fr.Element, m)
+		d.extend(0, zS, y, scratch, false)
+		for i := range zS {
+			zS[i].Add(&zS[i], &d.powers[j][m+i])
+		}
+		d.zInv[j] = fr.BatchInvert(zS)
+
+		d.exit(y, scratch)
+		y0 := make([]fr.Element, m)
+		y1 := make([]fr.Element, m)
+		copy(y0, y[:h])
+		copy(y1, y[h:])
+		d.enter(y0, scratch)
+		d.enter(y1, scratch)
+		for i := range y0 {
+			y0[i].Mul(&y0[i], &y1[i])
+			y1[i].Square(&y1[i])
+		}
+		d.exit(y0, scratch) // Y₀⋅Y₁
+		d.exit(y1, scratch) // Y₁²
+
+		q := make([]fr.Element, 2*m)
+		for i := 0; i < m; i++ {
+			q[i].Double(&y[i]).Add(&q[i], &y1[i])
+			if i < h {
+				var t fr.Element
+				t.Double(&y0[h+i])
+				q[i].Add(&q[i], &t)
+			}
+		}
+		q[m].SetOne()
+		d.enter(q, scratch)
+		d.q[j] = q
+	}
+}
+
+var three = func() fr.Element {
+	var t fr.Element
+	t.SetUint64(3)
+	return t
+}()
+
+// point is an affine point of a curve y² = x³ + a⋅x + b
+type point struct {
+	x, y fr.Element
+}
+
+// curveParameters returns the curve E₀: y² = x³ + a⋅x + b of the domains, a point g of order
+// 2^maxLogCardinality on E₀ and a point r such that 2r ∉ <g>
+func curveParameters() (a, b fr.Element, g, r point) {
+	a.SetString("-504409")
+	b.SetString("-28566120")
+	g.x.SetString("590492798051014738102773717335617783557349427661973329925334558754687212193")
+	g.y.SetString("946609825965767552328258826578202169180127251330500897070291634059096186978")
+	r.x.SetString("1")
+	r.y.SetString("1616183784817539735357597249539139505074986713942868147850564249967011154512")
+	return
+}
+
+// double returns [2]p, p not being of order 2
+func (p point) double(a *fr.Element) point {
+	var lambda, den fr.Element
+	lambda.Square(&p.x).Mul(&lambda, &three).Add(&lambda, a)
+	den.Double(&p.y).Inverse(&den)
+	lambda.Mul(&lambda, &den)
+	return p.chord(&lambda, &p)
+}
+
+// add returns p + q, given inv = 1/(q.x - p.x)
+func (p point) add(q point, inv *fr.Element) point {
+	var lambda fr.Element
+	lambda.Sub(&q.y, &p.y).Mul(&lambda, inv)
+	return p.chord(&lambda, &q)
+}
+
+// chord returns the opposite of the third intersection with the curve of the line of slope lambda
+// through p and q
+func (p point) chord(lambda *fr.Element, q *point) point {
+	var res point
+	res.x.Square(lambda).Sub(&res.x, &p.x).Sub(&res.x, &q.x)
+	res.y.Sub(&p.x, &res.x).Mul(&res.y, lambda).Sub(&res.y, &p.y)
+	return res
+}
+
+// cosetAbscissae returns the x(r + [i]g) for i < n, g of order n on y² = x³ + a⋅x + b
+func cosetAbscissae(r, g point, n uint64, a *fr.Element) []fr.Element {
+	coset := make([]point, 1, n)
+	coset[0] = r
+	den := make([]fr.Element, n/2)
+	for k := 1; k < int(n); k *= 2 {
+		if k > 1 {
+			g = g.double(a)
+		}
+		// coset[k+i] = coset[i] + [k]g
+		for i := 0; i < k; i++ {
+			den[i].Sub(&g.x, &coset[i].x)
+		}
+		inv := fr.BatchInvert(den[:k])
+		for i := 0; i < k; i++ {
+			coset = append(coset, coset[i].add(g, &inv[i]))
+		}
+	}
+
+	res := make([]fr.Element, n)
+	for i := range coset {
+		res[i] = coset[i].x
+	}
+	return res
+}
+
+// xDouble returns x([2]P) given x = x(P) on y² = x³ + a⋅x + b
+func xDouble(x, a, b *fr.Element) fr.Element {
+	var num, den, t fr.Element
+	num.Square(x).Sub(&num, a).Square(&num)
+	t.Mul(b, x).Double(&t).Double(&t).Double(&t)
+	num.Sub(&num, &t)
+	den.Square(x).Add(&den, a).Mul(&den, x).Add(&den, b)
+	den.Double(&den).Double(&den).Inverse(&den)
+	return *num.Mul(&num, &den)
+}
+
+// bitReverse applies the bit-reversal permutation to v, len(v) being a power of 2
+func bitReverse(v []fr.Element) {
+	n := uint64(len(v))
+	if n < 2 {
+		return
+	}
+	shift := 64 - uint64(bits.TrailingZeros64(n))
+	for i := uint64(0); i < n; i++ {
+		j := bits.Reverse64(i) >> shift
+		if i < j {
+			v[i], v[j] = v[j], v[i]
+		}
+	}
+}
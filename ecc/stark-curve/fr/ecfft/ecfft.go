@@ -0,0 +1,173 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecfft
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fr"
+)
+
+// Enter computes in place the evaluations on d.Points of the polynomial of degree less than
+// d.Cardinality whose coefficients (in canonical basis) are a.
+//
+// With P = P₀ + Xᵐ⋅P₁ (2m = len(a)), P₀ and P₁ are evaluated on Points[:m] recursively, and extended
+// to Points[m:2m].
+func (d *Domain) Enter(a []fr.Element) {
+	if uint64(len(a)) != d.Cardinality {
+		panic("ecfft: len(a) must be equal to the cardinality of the domain")
+	}
+	d.enter(a, make([]fr.Element, 3*len(a)))
+}
+
+// Exit computes in place the coefficients (in canonical basis) of the polynomial of degree less than
+// d.Cardinality whose evaluations on d.Points are a; it is the inverse of Enter.
+//
+// With P = P₀ + Xᵐ⋅P₁ (2m = len(a)), P₁ = P div Xᵐ is computed on Points[:2m] from the quotient of P
+// by the vanishing polynomial of Points[:m], then P₀ and P₁ are interpolated on Points[:m] recursively.
+func (d *Domain) Exit(a []fr.Element) {
+	if uint64(len(a)) != d.Cardinality {
+		panic("ecfft: len(a) must be equal to the cardinality of the domain")
+	}
+	d.exit(a, make([]fr.Element, 4*len(a)))
+}
+
+// Extend sets dst to the evaluations on d.Points[m:] of the polynomial of degree less than m
+// whose evaluations on d.Points[:m] are src, with m = d.Cardinality/2.
+func (d *Domain) Extend(dst, src []fr.Element) {
+	m := d.Cardinality / 2
+	if uint64(len(src)) != m || uint64(len(dst)) != m {
+		panic("ecfft: len(dst) and len(src) must be half the cardinality of the domain")
+	}
+	if m == 0 {
+		return
+	}
+	d.extend(0, dst, src, make([]fr.Element, 4*m), false)
+}
+
+// enter is Enter on Points[:len(a)]; scratch must hold 3⋅len(a) elements
+func (d *Domain) enter(a, scratch []fr.Element) {
+	n := len(a)
+	if n == 1 {
+		return
+	}
+	m := n / 2
+	j := bits.TrailingZeros(uint(m))
+	p0, p1 := a[:m], a[m:]
+	d.enter(p0, scratch)
+	d.enter(p1, scratch)
+
+	e0, e1 := scratch[:m], scratch[m:n]
+	d.extend(0, e0, p0, scratch[n:], false)
+	d.extend(0, e1, p1, scratch[n:], false)
+
+	powers := d.powers[j]
+	for i := 0; i < m; i++ {
+		var t fr.Element
+		t.Mul(&p1[i], &powers[i])
+		a[i].Add(&p0[i], &t)
+		t.Mul(&e1[i], &powers[m+i])
+		a[m+i].Add(&e0[i], &t)
+	}
+}
+
+// exit is Exit on Points[:len(a)]; scratch must hold 4⋅len(a) elements
+func (d *Domain) exit(a, scratch []fr.Element) {
+	n := len(a)
+	if n == 1 {
+		return
+	}
+	m := n / 2
+	j := bits.TrailingZeros(uint(m))
+	zInv, q, powers := d.zInv[j], d.q[j], d.powers[j]
+
+	// P = U⋅Z + R with Z the vanishing polynomial of Points[:m]; R = P on Points[:m],
+	// and U = (P - R)/Z on Points[m:2m]
+	u, e, rest := scratch[:n], scratch[n:n+m], scratch[n+m:]
+	d.extend(0, e, a[:m], rest, false)
+	for i := 0; i < m; i++ {
+		u[m+i].Sub(&a[m+i], &e[i]).Mul(&u[m+i], &zInv[i])
+	}
+	d.extend(0, u[:m], u[m:], rest, true)
+
+	// P₁ = P div Xᵐ = (U⋅Z) div Xᵐ = (U⋅(Z² div Xᵐ)) div Z
+	for i := range u {
+		u[i].Mul(&u[i], &q[i])
+	}
+	d.extend(0, e, u[:m], rest, false)
+	for i := 0; i < m; i++ {
+		u[m+i].Sub(&u[m+i], &e[i]).Mul(&u[m+i], &zInv[i])
+	}
+	d.extend(0, u[:m], u[m:], rest, true)
+
+	// P₀ = P - Xᵐ⋅P₁ on Points[:m]
+	for i := 0; i < m; i++ {
+		var t fr.Element
+		t.Mul(&u[i], &powers[i])
+		a[i].Sub(&a[i], &t)
+		a[m+i] = u[i]
+	}
+	d.exit(a[:m], scratch)
+	d.exit(a[m:], scratch)
+}
+
+// extend sets dst to the evaluations on points[m:2m] of the polynomial P of degree less than m = len(src)
+// whose evaluations on points[:m] are src, the points being those of the c-th level; if reverse,
+// the roles of points[:m] and points[m:2m] are swapped. scratch must hold 4m elements.
+//
+// With ψ = u/v the x-map of the isogeny and s, s' the two antecedents of a point of the next level,
+// P = (P₀(ψ) + X⋅P₁(ψ))⋅v^(m/2-1) for some P₀ and P₁ of degree less than m/2: their evaluations
+// on the next level are solved from those of P on s and s', extended recursively, and recombined.
+func (d *Domain) extend(c int, dst, src, scratch []fr.Element, reverse bool) {
+	m := len(src)
+	if m == 1 {
+		dst[0] = src[0]
+		return
+	}
+	h := m / 2
+	j := bits.TrailingZeros(uint(m))
+	l := &d.levels[c]
+	from, to := 0, m
+	if reverse {
+		from, to = m, 0
+	}
+	points, vPow, vInvPow := l.points, l.vPow[j], l.vInvPow[j]
+
+	p0, p1 := scratch[:h], scratch[h:m]
+	e0, e1 := scratch[m:m+h], scratch[m+h:2*m]
+	for i := 0; i < h; i++ {
+		s0, s1 := from+2*i, from+2*i+1
+		var q0, q1 fr.Element
+		q0.Mul(&src[2*i], &vInvPow[s0])
+		q1.Mul(&src[2*i+1], &vInvPow[s1])
+		p1[i].Sub(&q0, &q1).Mul(&p1[i], &l.pairInv[s0/2])
+		p0[i].Mul(&p1[i], &points[s0])
+		p0[i].Sub(&q0, &p0[i])
+	}
+
+	d.extend(c+1, e0, p0, scratch[2*m:], reverse)
+	d.extend(c+1, e1, p1, scratch[2*m:], reverse)
+
+	for i := 0; i < h; i++ {
+		for k := 0; k < 2; k++ {
+			s := to + 2*i + k
+			dst[2*i+k].Mul(&e1[i], &points[s]).
+				Add(&dst[2*i+k], &e0[i]).
+				Mul(&dst[2*i+k], &vPow[s])
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecfft
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fr"
+)
+
+func TestCurveParameters(t *testing.T) {
+	a, b, g, r := curveParameters()
+	onCurve := func(p point) bool {
+		var lhs, rhs fr.Element
+		lhs.Square(&p.y)
+		rhs.Square(&p.x).Add(&rhs, &a).Mul(&rhs, &p.x).Add(&rhs, &b)
+		return lhs.Equal(&rhs)
+	}
+	if !onCurve(g) || !onCurve(r) {
+		t.Fatal("G and R must be on the curve")
+	}
+
+	// G has order 2^maxLogCardinality
+	for i := 1; i < maxLogCardinality; i++ {
+		if g.y.IsZero() {
+			t.Fatalf("G has order 2^%d", i)
+		}
+		g = g.double(&a)
+	}
+	if !g.y.IsZero() {
+		t.Fatal("G has order larger than 2^maxLogCardinality")
+	}
+}
+
+func TestDomain(t *testing.T) {
+	for logSize := 0; logSize <= 8; logSize++ {
+		d := NewDomain(1 << logSize)
+		if d.Cardinality != 1<<logSize || len(d.Points) != 1<<logSize {
+			t.Fatal("wrong cardinality")
+		}
+
+		seen := make(map[fr.Element]bool)
+		for _, p := range d.Points {
+			if seen[p] {
+				t.Fatalf("size %d: the points of the domain must be distinct", d.Cardinality)
+			}
+			seen[p] = true
+		}
+
+		// the isogenies map the domain of a level 2-to-1 onto the next one
+		for c, l := range d.levels {
+			for i := 0; i < len(l.points)/2; i++ {
+				var next fr.Element
+				if c+1 < len(d.levels) {
+					next = d.levels[c+1].points[i]
+				} else {
+					// the last level maps to 2 points only
+					next = l.psi(&l.points[2*i])
+				}
+				p0, p1 := l.psi(&l.points[2*i]), l.psi(&l.points[2*i+1])
+				if !p0.Equal(&next) || !p1.Equal(&next) {
+					t.Fatalf("size %d, level %d: ψ(points[%d]) and ψ(points[%d]) must be the %d-th point of the next level", d.Cardinality, c, 2*i, 2*i+1, i)
+				}
+			}
+		}
+	}
+}
+
+func TestEnterExit(t *testing.T) {
+	for logSize := 0; logSize <= 8; logSize++ {
+		d := NewDomain(1 << logSize)
+
+		pol := make(fr.Vector, d.Cardinality)
+		for i := range pol {
+			pol[i].SetRandom()
+		}
+		evaluations := make(fr.Vector, d.Cardinality)
+		copy(evaluations, pol)
+		d.Enter(evaluations)
+
+		for i := range d.Points {
+			expected := eval(pol, &d.Points[i])
+			if !evaluations[i].Equal(&expected) {
+				t.Fatalf("size %d: Enter mismatch at index %d", d.Cardinality, i)
+			}
+		}
+
+		d.Exit(evaluations)
+		for i := range pol {
+			if !evaluations[i].Equal(&pol[i]) {
+				t.Fatalf("size %d: Exit(Enter) != id at index %d", d.Cardinality, i)
+			}
+		}
+	}
+}
+
+func TestExtend(t *testing.T) {
+	for logSize := 1; logSize <= 8; logSize++ {
+		d := NewDomain(1 << logSize)
+		m := d.Cardinality / 2
+
+		pol := make(fr.Vector, m)
+		for i := range pol {
+			pol[i].SetRandom()
+		}
+		src := make(fr.Vector, m)
+		for i := range src {
+			src[i] = eval(pol, &d.Points[i])
+		}
+		dst := make(fr.Vector, m)
+		d.Extend(dst, src)
+
+		for i := range dst {
+			expected := eval(pol, &d.Points[int(m)+i])
+			if !dst[i].Equal(&expected) {
+				t.Fatalf("size %d: Extend mismatch at index %d", d.Cardinality, i)
+			}
+		}
+	}
+}
+
+func TestNewDomainTooBig(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	NewDomain(1<<maxLogCardinality + 1)
+}
+
+// eval returns the evaluation of pol at x, by Horner's method
+func eval(pol []fr.Element, x *fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(pol) - 1; i >= 0; i-- {
+		res.Mul(&res, x).Add(&res, &pol[i])
+	}
+	return res
+}
+
+func BenchmarkNewDomain(b *testing.B) {
+	for _, logSize := range []int{10, 14} {
+		b.Run("size="+strconv.Itoa(1<<logSize), func(b *testing.B) {
+			for j := 0; j < b.N; j++ {
+				NewDomain(1 << logSize)
+			}
+		})
+	}
+}
+
+func BenchmarkEnterExit(b *testing.B) {
+	for _, logSize := range []int{10, 14} {
+		d := NewDomain(1 << logSize)
+		pol := make(fr.Vector, d.Cardinality)
+		for i := range pol {
+			pol[i].SetRandom()
+		}
+		b.Run("Enter/size="+strconv.Itoa(1<<logSize), func(b *testing.B) {
+			for j := 0; j < b.N; j++ {
+				d.Enter(pol)
+			}
+		})
+		b.Run("Exit/size="+strconv.Itoa(1<<logSize), func(b *testing.B) {
+			for j := 0; j < b.N; j++ {
+				d.Exit(pol)
+			}
+		})
+	}
+}
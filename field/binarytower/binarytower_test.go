@@ -0,0 +1,289 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binarytower
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const nbRandomTests = 1000
+
+// mulReference returns x⋅y in the tower, x and y being given bit by bit (x[i] is the i-th bit), with
+// the schoolbook product (a + b⋅Xₖ₋₁)⋅(c + d⋅Xₖ₋₁) = (a⋅c + b⋅d) + (a⋅d + b⋅c + b⋅d⋅Xₖ₋₂)⋅Xₖ₋₁
+func mulReference(x, y []uint8) []uint8 {
+	n := len(x)
+	if n == 1 {
+		return []uint8{x[0] & y[0]}
+	}
+	h := n / 2
+	a, b, c, d := x[:h], x[h:], y[:h], y[h:]
+	alpha := make([]uint8, h) // Xₖ₋₂, or 1 for n = 2
+	alpha[h/2] = 1
+	bd := mulReference(b, d)
+	c0 := xorBits(mulReference(a, c), bd)
+	c1 := xorBits(xorBits(mulReference(a, d), mulReference(b, c)), mulReference(bd, alpha))
+	return append(c0, c1...)
+}
+
+func xorBits(x, y []uint8) []uint8 {
+	res := make([]uint8, len(x))
+	for i := range res {
+		res[i] = x[i] ^ y[i]
+	}
+	return res
+}
+
+// toBits returns the n low bits of the little-endian limbs v
+func toBits(v []uint64, n int) []uint8 {
+	res := make([]uint8, n)
+	for i := range res {
+		res[i] = uint8(v[i/64]>>(i%64)) & 1
+	}
+	return res
+}
+
+func TestMul(t *testing.T) {
+	assert := require.New(t)
+
+	// E8 exhaustively
+	for x := 0; x < 256; x++ {
+		for y := 0; y < 256; y++ {
+			a, b := E8(x), E8(y)
+			var c E8
+			c.Mul(&a, &b)
+			assert.Equal(mulReference(toBits([]uint64{uint64(x)}, 8), toBits([]uint64{uint64(y)}, 8)), toBits([]uint64{uint64(c)}, 8))
+		}
+	}
+
+	for i := 0; i < nbRandomTests; i++ {
+		var a16, b16, c16 E16
+		a16.SetRandom()
+		b16.SetRandom()
+		c16.Mul(&a16, &b16)
+		assert.Equal(mulReference(toBits([]uint64{uint64(a16)}, 16), toBits([]uint64{uint64(b16)}, 16)), toBits([]uint64{uint64(c16)}, 16))
+
+		var a32, b32, c32 E32
+		a32.SetRandom()
+		b32.SetRandom()
+		c32.Mul(&a32, &b32)
+		assert.Equal(mulReference(toBits([]uint64{uint64(a32)}, 32), toBits([]uint64{uint64(b32)}, 32)), toBits([]uint64{uint64(c32)}, 32))
+
+		var a64, b64, c64 E64
+		a64.SetRandom()
+		b64.SetRandom()
+		c64.Mul(&a64, &b64)
+		assert.Equal(mulReference(toBits([]uint64{uint64(a64)}, 64), toBits([]uint64{uint64(b64)}, 64)), toBits([]uint64{uint64(c64)}, 64))
+
+		var a128, b128, c128 E128
+		a128.SetRandom()
+		b128.SetRandom()
+		c128.Mul(&a128, &b128)
+		assert.Equal(mulReference(toBits(a128[:], 128), toBits(b128[:], 128)), toBits(c128[:], 128))
+	}
+
+	// X₀² = X₀ + 1 and X₆² = X₅⋅X₆ + 1
+	var x0, x6 E128
+	x0.SetUint64(2)
+	assert.Equal(E128{3, 0}, *x0.Mul(&x0, &x0))
+	x6 = E128{0, 1}
+	assert.Equal(E128{1, 1 << 32}, *x6.Mul(&x6, &x6))
+}
+
+func TestSquare(t *testing.T) {
+	assert := require.New(t)
+
+	for i := 0; i < nbRandomTests; i++ {
+		var a16, b16, c16 E16
+		a16.SetRandom()
+		assert.Equal(*b16.Mul(&a16, &a16), *c16.Square(&a16))
+
+		var a32, b32, c32 E32
+		a32.SetRandom()
+		assert.Equal(*b32.Mul(&a32, &a32), *c32.Square(&a32))
+
+		var a64, b64, c64 E64
+		a64.SetRandom()
+		assert.Equal(*b64.Mul(&a64, &a64), *c64.Square(&a64))
+
+		var a128, b128, c128 E128
+		a128.SetRandom()
+		assert.Equal(*b128.Mul(&a128, &a128), *c128.Square(&a128))
+	}
+}
+
+func TestInverse(t *testing.T) {
+	assert := require.New(t)
+
+	for i := 0; i < nbRandomTests; i++ {
+		var a16, b16 E16
+		a16.SetRandom()
+		if !a16.IsZero() {
+			assert.True(b16.Inverse(&a16).Mul(&b16, &a16).IsOne())
+		}
+
+		var a32, b32 E32
+		a32.SetRandom()
+		if !a32.IsZero() {
+			assert.True(b32.Inverse(&a32).Mul(&b32, &a32).IsOne())
+		}
+
+		var a64, b64 E64
+		a64.SetRandom()
+		if !a64.IsZero() {
+			assert.True(b64.Inverse(&a64).Mul(&b64, &a64).IsOne())
+		}
+
+		var a128, b128 E128
+		a128.SetRandom()
+		if !a128.IsZero() {
+			assert.True(b128.Inverse(&a128).Mul(&b128, &a128).IsOne())
+			assert.True(b128.Div(&a128, &a128).IsOne())
+		}
+	}
+
+	// every element of E8 is invertible but 0
+	for v := 0; v < 256; v++ {
+		a, b := E8(v), E8(0)
+		b.Inverse(&a)
+		assert.Equal(v != 0, b.Mul(&b, &a).IsOne())
+	}
+	var z E64
+	assert.True(z.Inverse(&z).IsZero())
+	var z128 E128
+	assert.True(z128.Inverse(&z128).IsZero())
+}
+
+func TestEmbeddings(t *testing.T) {
+	assert := require.New(t)
+
+	// the embeddings are injective ring morphisms
+	for i := 0; i < nbRandomTests; i++ {
+		var a8, b8, c8 E8
+		a8.SetRandom()
+		b8.SetRandom()
+		var a16, b16, c16, d16 E16
+		a16.SetE8(&a8)
+		b16.SetE8(&b8)
+		c16.SetE8(c8.Mul(&a8, &b8))
+		assert.Equal(c16, *d16.Mul(&a16, &b16))
+		c16.SetE8(c8.Add(&a8, &b8))
+		assert.Equal(c16, *d16.Add(&a16, &b16))
+
+		var a32, b32, c32, d32 E32
+		a32.SetE16(&a16)
+		b32.SetE16(&b16)
+		c32.SetE16(c16.Mul(&a16, &b16))
+		assert.Equal(c32, *d32.Mul(&a32, &b32))
+
+		var a64, b64, c64, d64 E64
+		a64.SetE32(&a32)
+		b64.SetE32(&b32)
+		c64.SetE32(c32.Mul(&a32, &b32))
+		assert.Equal(c64, *d64.Mul(&a64, &b64))
+
+		// zero-extensions
+		assert.Equal(uint64(a8), uint64(a16))
+		assert.Equal(uint64(a16), uint64(a32))
+		assert.Equal(uint64(a32), uint64(a64))
+
+		var a128, b128, c128, d128 E128
+		a64.SetRandom()
+		b64.SetRandom()
+		a128.SetE64(&a64)
+		b128.SetE64(&b64)
+		c128.SetE64(c64.Mul(&a64, &b64))
+		assert.Equal(c128, *d128.Mul(&a128, &b128))
+		assert.Equal(E128{uint64(a64), 0}, a128)
+	}
+
+	var one8 E8
+	var one128 E128
+	one8.SetOne()
+	var one16 E16
+	var one32 E32
+	var one64 E64
+	one16.SetE8(&one8)
+	one32.SetE16(&one16)
+	one64.SetE32(&one32)
+	assert.True(one128.SetE64(&one64).IsOne())
+}
+
+func TestBytes(t *testing.T) {
+	assert := require.New(t)
+
+	var a, b E128
+	a.SetRandom()
+	encoded := a.Bytes()
+	_, err := b.SetBytes(encoded[:])
+	assert.NoError(err)
+	assert.True(a.Equal(&b))
+	_, err = b.SetBytes(encoded[1:])
+	assert.Error(err)
+
+	var c, d E32
+	c.SetUint64(0x01020304)
+	encoded32 := c.Bytes()
+	assert.Equal([SizeOfE32]byte{1, 2, 3, 4}, encoded32)
+	_, err = d.SetBytes(encoded32[:])
+	assert.NoError(err)
+	assert.Equal("0x01020304", d.String())
+}
+
+func BenchmarkMul(b *testing.B) {
+	var a8, c8 E8
+	var a16, c16 E16
+	var a32, c32 E32
+	var a64, c64 E64
+	var a128, c128 E128
+	a8.SetRandom()
+	a16.SetRandom()
+	a32.SetRandom()
+	a64.SetRandom()
+	a128.SetRandom()
+	c8, c16, c32, c64, c128 = a8, a16, a32, a64, a128
+
+	b.Run("E8", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			c8.Mul(&c8, &a8)
+		}
+	})
+	b.Run("E16", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			c16.Mul(&c16, &a16)
+		}
+	})
+	b.Run("E32", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			c32.Mul(&c32, &a32)
+		}
+	})
+	b.Run("E64", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			c64.Mul(&c64, &a64)
+		}
+	})
+	b.Run("E128", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			c128.Mul(&c128, &a128)
+		}
+	})
+	b.Run("E128/Inverse", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			c128.Inverse(&c128)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package binarytower provides the binary fields GF(2⁸), GF(2¹⁶), GF(2³²), GF(2⁶⁴) and GF(2¹²⁸) as the
+// levels 3 to 7 of the Wiedemann (Fan–Paar) tower of iterated quadratic extensions of GF(2):
+//
+//	τ₀ = GF(2)
+//	τₖ = τₖ₋₁[Xₖ₋₁]/(Xₖ₋₁² + Xₖ₋₂⋅Xₖ₋₁ + 1), with X₋₁ = 1
+//
+// E8 = τ₃, E16 = τ₄, E32 = τ₅, E64 = τ₆ and E128 = τ₇. An element a + b⋅Xₖ₋₁ of τₖ is stored on 2ᵏ bits,
+// the low half being a and the high half b, so that bit i is the coordinate of the monomial
+// X₀ⁱ⁰⋅X₁ⁱ¹⋯Xₖ₋₁ⁱᵏ⁻¹, where iⱼ is the j-th bit of i.
+//
+// Each field is a subfield of the next ones, and the embeddings (E16).SetE8, (E32).SetE16, (E64).SetE32
+// and (E128).SetE64 are zero-extensions: the image of a subfield element is a bit-prefix of its
+// extension, so data committed over a small field can be reinterpreted in place over a larger one.
+//
+// Addition is a XOR. Multiplications are computed level by level with Karatsuba (three products in
+// the subfield and a multiplication by Xₖ₋₂), down to E16 whose products use log and exp tables;
+// inverses are computed from the norm over the subfield, (a + b⋅Xₖ₋₁)⁻¹ = (a + b⋅Xₖ₋₂ + b⋅Xₖ₋₁) / N
+// with N = a⋅(a + b⋅Xₖ₋₂) + b². The products in this basis are not carryless products of the bit
+// strings, so no carryless multiplication instruction (PCLMULQDQ) is used.
+//
+// The additive FFT (see package fft) is implemented over any of the fields.
+package binarytower
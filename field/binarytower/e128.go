@@ -0,0 +1,143 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binarytower
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+)
+
+// SizeOfE128 is the number of bytes needed to represent an E128 element
+const SizeOfE128 = 16
+
+// E128 is an element of GF(2¹²⁸) = E64[X₆]/(X₆² + X₅⋅X₆ + 1), the level 7 of the tower: E128[0] is the
+// coefficient of 1 and E128[1] the coefficient of X₆, in E64
+type E128 [2]uint64
+
+// SetZero sets z to 0 and returns z
+func (z *E128) SetZero() *E128 {
+	*z = E128{}
+	return z
+}
+
+// SetOne sets z to 1 and returns z
+func (z *E128) SetOne() *E128 {
+	*z = E128{1, 0}
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *E128) Set(x *E128) *E128 {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z to the element whose 64 low coordinates are the bits of v and returns z
+func (z *E128) SetUint64(v uint64) *E128 {
+	*z = E128{v, 0}
+	return z
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E128) SetRandom() (*E128, error) {
+	var b [SizeOfE128]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+	return z.SetBytes(b[:])
+}
+
+// Equal returns true if z == x
+func (z *E128) Equal(x *E128) bool {
+	return *z == *x
+}
+
+// IsZero returns true if z == 0
+func (z *E128) IsZero() bool {
+	return (z[0] | z[1]) == 0
+}
+
+// IsOne returns true if z == 1
+func (z *E128) IsOne() bool {
+	return z[0] == 1 && z[1] == 0
+}
+
+// Add sets z = x + y and returns z
+func (z *E128) Add(x, y *E128) *E128 {
+	z[0] = x[0] ^ y[0]
+	z[1] = x[1] ^ y[1]
+	return z
+}
+
+// Sub sets z = x - y = x + y and returns z
+func (z *E128) Sub(x, y *E128) *E128 {
+	return z.Add(x, y)
+}
+
+// Mul sets z = x⋅y and returns z
+func (z *E128) Mul(x, y *E128) *E128 {
+	mulE128(z, x, y)
+	return z
+}
+
+// Square sets z = x² and returns z
+func (z *E128) Square(x *E128) *E128 {
+	squareE128(z, x)
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z; if x == 0, z is set to 0
+func (z *E128) Inverse(x *E128) *E128 {
+	inverseE128(z, x)
+	return z
+}
+
+// Div sets z = x/y and returns z; if y == 0, z is set to 0
+func (z *E128) Div(x, y *E128) *E128 {
+	var yInv E128
+	yInv.Inverse(y)
+	return z.Mul(x, &yInv)
+}
+
+// Bytes returns the big-endian encoding of z
+func (z *E128) Bytes() (res [SizeOfE128]byte) {
+	binary.BigEndian.PutUint64(res[:8], z[1])
+	binary.BigEndian.PutUint64(res[8:], z[0])
+	return
+}
+
+// SetBytes sets z from the big-endian encoding b, of length SizeOfE128, and returns z
+func (z *E128) SetBytes(b []byte) (*E128, error) {
+	if len(b) != SizeOfE128 {
+		return nil, errors.New("binarytower: invalid encoding length")
+	}
+	z[1] = binary.BigEndian.Uint64(b[:8])
+	z[0] = binary.BigEndian.Uint64(b[8:])
+	return z, nil
+}
+
+// String returns the hexadecimal big-endian encoding of z
+func (z E128) String() string {
+	b := z.Bytes()
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// SetE64 sets z to the image of x in the subfield GF(2⁶⁴) of E128, its zero-extension, and returns z
+func (z *E128) SetE64(x *E64) *E128 {
+	*z = E128{uint64(*x), 0}
+	return z
+}
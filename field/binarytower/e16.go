@@ -0,0 +1,141 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binarytower
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+)
+
+// SizeOfE16 is the number of bytes needed to represent an E16 element
+const SizeOfE16 = 2
+
+// E16 is an element of GF(2¹⁶) = E8[X₃]/(X₃² + X₂⋅X₃ + 1), the level 4 of the tower: the low
+// half of its bits is the coefficient of 1 and the high half the coefficient of X₃, in E8
+type E16 uint16
+
+// SetZero sets z to 0 and returns z
+func (z *E16) SetZero() *E16 {
+	*z = 0
+	return z
+}
+
+// SetOne sets z to 1 and returns z
+func (z *E16) SetOne() *E16 {
+	*z = 1
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *E16) Set(x *E16) *E16 {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z to the element whose coordinates are the 16 low bits of v and returns z
+func (z *E16) SetUint64(v uint64) *E16 {
+	*z = E16(v)
+	return z
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E16) SetRandom() (*E16, error) {
+	var b [SizeOfE16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+	return z.SetBytes(b[:])
+}
+
+// Equal returns true if z == x
+func (z *E16) Equal(x *E16) bool {
+	return *z == *x
+}
+
+// IsZero returns true if z == 0
+func (z *E16) IsZero() bool {
+	return *z == 0
+}
+
+// IsOne returns true if z == 1
+func (z *E16) IsOne() bool {
+	return *z == 1
+}
+
+// Add sets z = x + y and returns z
+func (z *E16) Add(x, y *E16) *E16 {
+	*z = *x ^ *y
+	return z
+}
+
+// Sub sets z = x - y = x + y and returns z
+func (z *E16) Sub(x, y *E16) *E16 {
+	*z = *x ^ *y
+	return z
+}
+
+// Mul sets z = x⋅y and returns z
+func (z *E16) Mul(x, y *E16) *E16 {
+	*z = E16(mulE16(uint16(*x), uint16(*y)))
+	return z
+}
+
+// Square sets z = x² and returns z
+func (z *E16) Square(x *E16) *E16 {
+	*z = E16(squareE16(uint16(*x)))
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z; if x == 0, z is set to 0
+func (z *E16) Inverse(x *E16) *E16 {
+	*z = E16(inverseE16(uint16(*x)))
+	return z
+}
+
+// Div sets z = x/y and returns z; if y == 0, z is set to 0
+func (z *E16) Div(x, y *E16) *E16 {
+	var yInv E16
+	yInv.Inverse(y)
+	return z.Mul(x, &yInv)
+}
+
+// Bytes returns the big-endian encoding of z
+func (z *E16) Bytes() (res [SizeOfE16]byte) {
+	binary.BigEndian.PutUint16(res[:], uint16(*z))
+	return
+}
+
+// SetBytes sets z from the big-endian encoding b, of length SizeOfE16, and returns z
+func (z *E16) SetBytes(b []byte) (*E16, error) {
+	if len(b) != SizeOfE16 {
+		return nil, errors.New("binarytower: invalid encoding length")
+	}
+	*z = E16(binary.BigEndian.Uint16(b))
+	return z, nil
+}
+
+// String returns the hexadecimal big-endian encoding of z
+func (z E16) String() string {
+	b := z.Bytes()
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// SetE8 sets z to the image of x in the subfield GF(2⁸) of E16, its zero-extension, and returns z
+func (z *E16) SetE8(x *E8) *E16 {
+	*z = E16(*x)
+	return z
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binarytower
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+)
+
+// SizeOfE32 is the number of bytes needed to represent an E32 element
+const SizeOfE32 = 4
+
+// E32 is an element of GF(2³²) = E16[X₄]/(X₄² + X₃⋅X₄ + 1), the level 5 of the tower: the low
+// half of its bits is the coefficient of 1 and the high half the coefficient of X₄, in E16
+type E32 uint32
+
+// SetZero sets z to 0 and returns z
+func (z *E32) SetZero() *E32 {
+	*z = 0
+	return z
+}
+
+// SetOne sets z to 1 and returns z
+func (z *E32) SetOne() *E32 {
+	*z = 1
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *E32) Set(x *E32) *E32 {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z to the element whose coordinates are the 32 low bits of v and returns z
+func (z *E32) SetUint64(v uint64) *E32 {
+	*z = E32(v)
+	return z
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E32) SetRandom() (*E32, error) {
+	var b [SizeOfE32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+	return z.SetBytes(b[:])
+}
+
+// Equal returns true if z == x
+func (z *E32) Equal(x *E32) bool {
+	return *z == *x
+}
+
+// IsZero returns true if z == 0
+func (z *E32) IsZero() bool {
+	return *z == 0
+}
+
+// IsOne returns true if z == 1
+func (z *E32) IsOne() bool {
+	return *z == 1
+}
+
+// Add sets z = x + y and returns z
+func (z *E32) Add(x, y *E32) *E32 {
+	*z = *x ^ *y
+	return z
+}
+
+// Sub sets z = x - y = x + y and returns z
+func (z *E32) Sub(x, y *E32) *E32 {
+	*z = *x ^ *y
+	return z
+}
+
+// Mul sets z = x⋅y and returns z
+func (z *E32) Mul(x, y *E32) *E32 {
+	*z = E32(mulE32(uint32(*x), uint32(*y)))
+	return z
+}
+
+// Square sets z = x² and returns z
+func (z *E32) Square(x *E32) *E32 {
+	*z = E32(squareE32(uint32(*x)))
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z; if x == 0, z is set to 0
+func (z *E32) Inverse(x *E32) *E32 {
+	*z = E32(inverseE32(uint32(*x)))
+	return z
+}
+
+// Div sets z = x/y and returns z; if y == 0, z is set to 0
+func (z *E32) Div(x, y *E32) *E32 {
+	var yInv E32
+	yInv.Inverse(y)
+	return z.Mul(x, &yInv)
+}
+
+// Bytes returns the big-endian encoding of z
+func (z *E32) Bytes() (res [SizeOfE32]byte) {
+	binary.BigEndian.PutUint32(res[:], uint32(*z))
+	return
+}
+
+// SetBytes sets z from the big-endian encoding b, of length SizeOfE32, and returns z
+func (z *E32) SetBytes(b []byte) (*E32, error) {
+	if len(b) != SizeOfE32 {
+		return nil, errors.New("binarytower: invalid encoding length")
+	}
+	*z = E32(binary.BigEndian.Uint32(b))
+	return z, nil
+}
+
+// String returns the hexadecimal big-endian encoding of z
+func (z E32) String() string {
+	b := z.Bytes()
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// SetE16 sets z to the image of x in the subfield GF(2¹⁶) of E32, its zero-extension, and returns z
+func (z *E32) SetE16(x *E16) *E32 {
+	*z = E32(*x)
+	return z
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binarytower
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+)
+
+// SizeOfE64 is the number of bytes needed to represent an E64 element
+const SizeOfE64 = 8
+
+// E64 is an element of GF(2⁶⁴) = E32[X₅]/(X₅² + X₄⋅X₅ + 1), the level 6 of the tower: the low
+// half of its bits is the coefficient of 1 and the high half the coefficient of X₅, in E32
+type E64 uint64
+
+// SetZero sets z to 0 and returns z
+func (z *E64) SetZero() *E64 {
+	*z = 0
+	return z
+}
+
+// SetOne sets z to 1 and returns z
+func (z *E64) SetOne() *E64 {
+	*z = 1
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *E64) Set(x *E64) *E64 {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z to the element whose coordinates are the 64 low bits of v and returns z
+func (z *E64) SetUint64(v uint64) *E64 {
+	*z = E64(v)
+	return z
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E64) SetRandom() (*E64, error) {
+	var b [SizeOfE64]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+	return z.SetBytes(b[:])
+}
+
+// Equal returns true if z == x
+func (z *E64) Equal(x *E64) bool {
+	return *z == *x
+}
+
+// IsZero returns true if z == 0
+func (z *E64) IsZero() bool {
+	return *z == 0
+}
+
+// IsOne returns true if z == 1
+func (z *E64) IsOne() bool {
+	return *z == 1
+}
+
+// Add sets z = x + y and returns z
+func (z *E64) Add(x, y *E64) *E64 {
+	*z = *x ^ *y
+	return z
+}
+
+// Sub sets z = x - y = x + y and returns z
+func (z *E64) Sub(x, y *E64) *E64 {
+	*z = *x ^ *y
+	return z
+}
+
+// Mul sets z = x⋅y and returns z
+func (z *E64) Mul(x, y *E64) *E64 {
+	*z = E64(mulE64(uint64(*x), uint64(*y)))
+	return z
+}
+
+// Square sets z = x² and returns z
+func (z *E64) Square(x *E64) *E64 {
+	*z = E64(squareE64(uint64(*x)))
+	return z
+}
+
+// Inverse sets z = x⁻¹ and returns z; if x == 0, z is set to 0
+func (z *E64) Inverse(x *E64) *E64 {
+	*z = E64(inverseE64(uint64(*x)))
+	return z
+}
+
+// Div sets z = x/y and returns z; if y == 0, z is set to 0
+func (z *E64) Div(x, y *E64) *E64 {
+	var yInv E64
+	yInv.Inverse(y)
+	return z.Mul(x, &yInv)
+}
+
+// Bytes returns the big-endian encoding of z
+func (z *E64) Bytes() (res [SizeOfE64]byte) {
+	binary.BigEndian.PutUint64(res[:], uint64(*z))
+	return
+}
+
+// SetBytes sets z from the big-endian encoding b, of length SizeOfE64, and returns z
+func (z *E64) SetBytes(b []byte) (*E64, error) {
+	if len(b) != SizeOfE64 {
+		return nil, errors.New("binarytower: invalid encoding length")
+	}
+	*z = E64(binary.BigEndian.Uint64(b))
+	return z, nil
+}
+
+// String returns the hexadecimal big-endian encoding of z
+func (z E64) String() string {
+	b := z.Bytes()
+	return "0x" + hex.EncodeToString(b[:])
+}
+
+// SetE32 sets z to the image of x in the subfield GF(2³²) of E64, its zero-extension, and returns z
+func (z *E64) SetE32(x *E32) *E64 {
+	*z = E64(*x)
+	return z
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binarytower
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+)
+
+// SizeOfE8 is the number of bytes needed to represent an E8 element
+const SizeOfE8 = 1
+
+// E8 is an element of GF(2⁸), the level 3 of the tower: bit i is the coefficient of X₀ⁱ⁰⋅X₁ⁱ¹⋅X₂ⁱ² where
+// i = i₀ + 2⋅i₁ + 4⋅i₂ (see the package documentation)
+type E8 uint8
+
+// SetZero sets z to 0 and returns z
+func (z *E8) SetZero() *E8 {
+	*z = 0
+	return z
+}
+
+// SetOne sets z to 1 and returns z
+func (z *E8) SetOne() *E8 {
+	*z = 1
+	return z
+}
+
+// Set sets z to x and returns z
+func (z *E8) Set(x *E8) *E8 {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z to the element whose coordinates are the 8 low bits of v and returns z
+func (z *E8) SetUint64(v uint64) *E8 {
+	*z = E8(v)
+	return z
+}
+
+// SetRandom sets z to a uniform random value and returns z
+func (z *E8) SetRandom() (*E8, error) {
+	var b [SizeOfE8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+	return z.SetBytes(b[:])
+}
+
+// Equal returns true if z == x
+func (z *E8) Equal(x *E8) bool {
+	return *z == *x
+}
+
+// IsZero returns true if z == 0
+func (z *E8) IsZero() bool {
+	return *z == 0
+}
+
+// IsOne returns true if z == 1
+func (z *E8) IsOne() bool {
+	return *z == 1
+}
+
+// Add sets z = x + y and returns z
+func (z *E8) Add(x, y *E8) *E8 {
+	*z = *x ^ *y
+	return z
+}
+
+// Sub sets z = x - y = x + y and returns z
+func (z *E8) Sub(x, y *E8) *E8 {
+	*z = *x ^ *y
+	return z
+}
+
+// Mul sets z = x⋅y and returns z
+func (z *E8) Mul(x, y *E8) *E8 {
+	*z = E8(mulE8(uint8(*x), uint8(*y)))
+	return z
+}
+
+// Square sets z = x² and returns z
+func (z *E8) Square(x *E8) *E8 {
+	return z.Mul(x, x)
+}
+
+// Inverse sets z = x⁻¹ and returns z; if x == 0, z is set to 0
+func (z *E8) Inverse(x *E8) *E8 {
+	*z = E8(e8Inv[*x])
+	return z
+}
+
+// Div sets z = x/y and returns z; if y == 0, z is set to 0
+func (z *E8) Div(x, y *E8) *E8 {
+	var yInv E8
+	yInv.Inverse(y)
+	return z.Mul(x, &yInv)
+}
+
+// Bytes returns the big-endian encoding of z
+func (z *E8) Bytes() (res [SizeOfE8]byte) {
+	res[0] = byte(*z)
+	return
+}
+
+// SetBytes sets z from the big-endian encoding b, of length SizeOfE8, and returns z
+func (z *E8) SetBytes(b []byte) (*E8, error) {
+	if len(b) != SizeOfE8 {
+		return nil, errors.New("binarytower: invalid encoding length")
+	}
+	*z = E8(b[0])
+	return z, nil
+}
+
+// String returns the hexadecimal big-endian encoding of z
+func (z E8) String() string {
+	b := z.Bytes()
+	return "0x" + hex.EncodeToString(b[:])
+}
@@ -0,0 +1,41 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fft provides the additive FFT of Lin, Chung and Han over the binary fields of the tower
+// binarytower.E8, ..., binarytower.E128 (Novel polynomial basis and its application to Reed-Solomon
+// erasure codes, https://arxiv.org/abs/1404.3458).
+//
+// Binary fields have no large multiplicative subgroups of order 2ⁿ; their evaluation domains are
+// instead affine GF(2)-subspaces of size N = 2ⁿ:
+//
+//	D = {s + ωₖ, 0 ≤ k < N}, with ωₖ = k₀⋅β₀ + k₁⋅β₁ + ⋯ + kₙ₋₁⋅βₙ₋₁
+//
+// where kᵢ is the i-th bit of k, βᵢ is the element whose i-th bit is set (ωₖ is the element whose bits
+// are those of k) and s is the shift of the domain. In the tower basis, β₀, ..., β₂ᵏ₋₁ span the
+// subfield of 2^(2ᵏ) elements: the domain of size 2⁸ of E128 is E8, and a domain over a subfield and its
+// FFT are, zero-extended, the domain with the same shift over a larger field and its FFT.
+//
+// With Vᵢ the subspace spanned by β₀, ..., βᵢ₋₁, the subspace polynomials Wᵢ(X) = ∏_{v ∈ Vᵢ} (X - v)
+// are GF(2)-linear, and so are their normalizations Ŵᵢ = Wᵢ/Wᵢ(βᵢ). A polynomial over a domain of
+// size N is represented by its coordinates c₀, ..., c_{N-1} in the novel polynomial basis
+//
+//	Xₖ(X) = Ŵ₀(X)ᵏ⁰ ⋅ Ŵ₁(X)ᵏ¹ ⋯ Ŵₙ₋₁(X)ᵏⁿ⁻¹
+//
+// in which the FFT and its inverse take N/2⋅log₂(N) multiplications.
+//
+// The domains and the FFT are generic over the field, for instance:
+//
+//	domain := fft.NewDomain[binarytower.E32](1 << 10)
+//	domain.FFT(a) // a []binarytower.E32
+package fft
@@ -0,0 +1,135 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// Element is a constraint satisfied by the pointer type *T of the fields of the tower,
+// binarytower.E8, ..., binarytower.E128.
+type Element[T any] interface {
+	*T
+
+	SetUint64(v uint64) *T
+	Add(x, y *T) *T
+	Mul(x, y *T) *T
+	Inverse(x *T) *T
+	Div(x, y *T) *T
+	IsZero() bool
+}
+
+// Domain is the affine subspace {s + ωₖ, 0 ≤ k < N} of the field of T, of size N = 2ⁿ
+// (see the package documentation).
+type Domain[T any, PT Element[T]] struct {
+	Cardinality uint64
+
+	// Shift is s, the first point of the domain
+	Shift T
+
+	// Twiddles[i][u] is Ŵᵢ(s + ω_{u⋅2ⁱ⁺¹}), for 0 ≤ i < n and u < N/2ⁱ⁺¹: Ŵᵢ is constant on each
+	// coset of Vᵢ₊₁ of the domain, and takes the value Twiddles[i][u] + 1 on its half s + ω_{u⋅2ⁱ⁺¹} + βᵢ + Vᵢ.
+	Twiddles [][]T
+}
+
+// MaxLogCardinality is log₂ of the largest supported domain; a domain over a field of 2ᵏ elements
+// has at most 2ᵏ points.
+const MaxLogCardinality = 32
+
+// NewDomain returns the subspace domain of size m, rounded up to the next power of two
+//
+// For instance, NewDomain[binarytower.E128](1 << 8) has the same points as
+// NewDomain[binarytower.E8](1 << 8), the subfield E8 of E128.
+func NewDomain[T any, PT Element[T]](m uint64) *Domain[T, PT] {
+	var shift T
+	return NewCosetDomain[T, PT](m, shift)
+}
+
+// NewCosetDomain returns the affine subspace domain of size m, rounded up to the next power of two,
+// shifted by shift
+func NewCosetDomain[T any, PT Element[T]](m uint64, shift T) *Domain[T, PT] {
+	n := ecc.NextPowerOfTwo(m)
+	logN := bits.TrailingZeros64(n)
+	if logN > MaxLogCardinality {
+		panic(fmt.Sprintf("binary field domains are limited to 2^%d elements", MaxLogCardinality))
+	}
+	if logN > 0 {
+		// βₙ₋₁ must be an element of the field
+		if b := basis[T, PT](logN - 1); PT(&b).IsZero() {
+			panic(fmt.Sprintf("the field has less than 2^%d elements", logN))
+		}
+	}
+
+	domain := &Domain[T, PT]{Cardinality: n, Shift: shift}
+	domain.preComputeTwiddles()
+
+	return domain
+}
+
+// Point returns the k-th point s + ωₖ of the domain
+func (d *Domain[T, PT]) Point(k uint64) T {
+	var p T
+	PT(&p).SetUint64(k % d.Cardinality) // ωₖ = ∑ kᵢ⋅βᵢ
+	PT(&p).Add(&p, &d.Shift)
+	return p
+}
+
+func (d *Domain[T, PT]) preComputeTwiddles() {
+	n := bits.TrailingZeros64(d.Cardinality)
+	d.Twiddles = make([][]T, n)
+
+	// wBeta[j] = Wᵢ(βⱼ) for j ≥ i and wShift = Wᵢ(s), starting from W₀(X) = X
+	wBeta := make([]T, n)
+	for j := range wBeta {
+		wBeta[j] = basis[T, PT](j)
+	}
+	wShift := d.Shift
+
+	for i := 0; i < n; i++ {
+		var normInv T
+		PT(&normInv).Inverse(&wBeta[i])
+
+		// Ŵᵢ is GF(2)-linear: Ŵᵢ(s + ω_{u⋅2ⁱ⁺¹}) = Ŵᵢ(s) + ∑ uₖ⋅Ŵᵢ(βᵢ₊₁₊ₖ)
+		t := make([]T, 1<<(n-i-1))
+		PT(&t[0]).Mul(&wShift, &normInv)
+		for k := 0; i+1+k < n; k++ {
+			var w T
+			PT(&w).Mul(&wBeta[i+1+k], &normInv)
+			for r := 0; r < 1<<k; r++ {
+				PT(&t[1<<k+r]).Add(&t[r], &w)
+			}
+		}
+		d.Twiddles[i] = t
+
+		// Wᵢ₊₁(X) = Wᵢ(X)⋅Wᵢ(X + βᵢ) = Wᵢ(X)⋅(Wᵢ(X) + Wᵢ(βᵢ))
+		var tmp T
+		for j := i + 1; j < n; j++ {
+			PT(&tmp).Add(&wBeta[j], &wBeta[i])
+			PT(&wBeta[j]).Mul(&wBeta[j], &tmp)
+		}
+		PT(&tmp).Add(&wShift, &wBeta[i])
+		PT(&wShift).Mul(&wShift, &tmp)
+	}
+}
+
+// basis returns βᵢ, the element whose i-th bit is set (zero if the field has less than i+1 bits)
+func basis[T any, PT Element[T]](i int) T {
+	var b T
+	PT(&b).SetUint64(1 << i)
+	return b
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import "math/bits"
+
+// FFT evaluates the polynomial with coordinates a (in the novel polynomial basis described in the
+// package documentation) on the domain. The result is in place, in the natural order of the
+// domain: a[k] = P(d.Point(k)).
+func (d *Domain[T, PT]) FFT(a []T) {
+	if uint64(len(a)) != d.Cardinality {
+		panic("invalid input size")
+	}
+	n := len(a)
+
+	// P = P₀ + Ŵᵢ⋅P₁ on each coset of Vᵢ₊₁, on whose halves Ŵᵢ is t and t + 1
+	var tmp T
+	for i := len(d.Twiddles) - 1; i >= 0; i-- {
+		h := 1 << i
+		tw := d.Twiddles[i]
+		for u, s := 0, 0; s < n; u, s = u+1, s+2*h {
+			for j := s; j < s+h; j++ {
+				PT(&tmp).Mul(&a[j+h], &tw[u])
+				PT(&a[j]).Add(&a[j], &tmp)
+				PT(&a[j+h]).Add(&a[j+h], &a[j])
+			}
+		}
+	}
+}
+
+// FFTInverse computes in place the coordinates (in the novel polynomial basis) of the polynomial
+// of degree less than d.Cardinality whose evaluations on the domain are a, in natural order.
+// It is the inverse of FFT.
+func (d *Domain[T, PT]) FFTInverse(a []T) {
+	if uint64(len(a)) != d.Cardinality {
+		panic("invalid input size")
+	}
+	n := len(a)
+
+	var tmp T
+	for i := range d.Twiddles {
+		h := 1 << i
+		tw := d.Twiddles[i]
+		for u, s := 0, 0; s < n; u, s = u+1, s+2*h {
+			for j := s; j < s+h; j++ {
+				PT(&a[j+h]).Add(&a[j+h], &a[j])
+				PT(&tmp).Mul(&a[j+h], &tw[u])
+				PT(&a[j]).Add(&a[j], &tmp)
+			}
+		}
+	}
+}
+
+// Evaluate returns the evaluation at p of the polynomial with coordinates
+// coeffs (in the novel polynomial basis described in the package documentation).
+// len(coeffs) must be a power of two.
+func Evaluate[T any, PT Element[T]](coeffs []T, p T) T {
+	n := len(coeffs)
+	if n == 0 {
+		var zero T
+		return zero
+	}
+	if n&(n-1) != 0 {
+		panic("the number of coefficients must be a power of two")
+	}
+	logN := bits.TrailingZeros(uint(n))
+
+	// Wᵢ(p) and Wᵢ(βⱼ) for j ≥ i, as in preComputeTwiddles
+	wBeta := make([]T, logN)
+	for j := range wBeta {
+		wBeta[j] = basis[T, PT](j)
+	}
+	wP := p
+
+	v := make([]T, n)
+	copy(v, coeffs)
+
+	// fold the lowest bit of the index with Ŵ₀(p), then the next ones with Ŵ₁(p), ...
+	var f, tmp T
+	for i, m := 0, n; m > 1; i, m = i+1, m/2 {
+		PT(&f).Div(&wP, &wBeta[i])
+		for k := 0; k < m/2; k++ {
+			PT(&tmp).Mul(&v[2*k+1], &f)
+			PT(&v[k]).Add(&v[2*k], &tmp)
+		}
+
+		for j := i + 1; j < logN; j++ {
+			PT(&tmp).Add(&wBeta[j], &wBeta[i])
+			PT(&wBeta[j]).Mul(&wBeta[j], &tmp)
+		}
+		PT(&tmp).Add(&wP, &wBeta[i])
+		PT(&wP).Mul(&wP, &tmp)
+	}
+	return v[0]
+}
@@ -0,0 +1,195 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/field/binarytower"
+	"github.com/stretchr/testify/require"
+)
+
+// randomElement is satisfied by the pointer types of the fields of the tower
+type randomElement[T any] interface {
+	Element[T]
+	SetRandom() (*T, error)
+	Equal(x *T) bool
+}
+
+func TestFFT(t *testing.T) {
+	t.Run("E8", testFFT[binarytower.E8])
+	t.Run("E16", testFFT[binarytower.E16])
+	t.Run("E32", testFFT[binarytower.E32])
+	t.Run("E64", testFFT[binarytower.E64])
+	t.Run("E128", testFFT[binarytower.E128])
+}
+
+func testFFT[T any, PT randomElement[T]](t *testing.T) {
+	assert := require.New(t)
+
+	var shift T
+	PT(&shift).SetRandom()
+
+	for _, n := range []uint64{1, 2, 4, 8, 64, 1 << 8} {
+		for _, domain := range []*Domain[T, PT]{NewDomain[T, PT](n), NewCosetDomain[T, PT](n, shift)} {
+			assert.Equal(n, domain.Cardinality)
+
+			coeffs := randomVector[T, PT](int(n))
+			evals := make([]T, n)
+			copy(evals, coeffs)
+			domain.FFT(evals)
+
+			for i := uint64(0); i < n; i++ {
+				expected := Evaluate[T, PT](coeffs, domain.Point(i))
+				assert.True(PT(&expected).Equal(&evals[i]), "FFT mismatch at index %d (size %d)", i, n)
+			}
+
+			domain.FFTInverse(evals)
+			assert.Equal(coeffs, evals, "FFTInverse(FFT(a)) != a (size %d)", n)
+		}
+	}
+}
+
+func TestDomainSize(t *testing.T) {
+	assert := require.New(t)
+
+	// the domains are limited by the size of the field
+	assert.NotPanics(func() { NewDomain[binarytower.E8](1 << 8) })
+	assert.Panics(func() { NewDomain[binarytower.E8](1 << 9) })
+	assert.Panics(func() { NewDomain[binarytower.E16](1 << 17) })
+}
+
+func TestNovelBasis(t *testing.T) {
+	assert := require.New(t)
+
+	// Ŵᵢ(βᵢ) = 1 and Ŵᵢ vanishes on Vᵢ: the basis polynomial X_{2ⁱ} = Ŵᵢ is 1 on βᵢ and 0 on β₀, ..., βᵢ₋₁
+	const n = 16
+	for i := 0; i < 4; i++ {
+		coeffs := make([]binarytower.E128, n)
+		coeffs[1<<i].SetOne()
+		for j := 0; j <= i; j++ {
+			e := Evaluate[binarytower.E128](coeffs, basis[binarytower.E128](j))
+			assert.Equal(j == i, e.IsOne(), "Ŵ%d(β%d)", i, j)
+			assert.Equal(j != i, e.IsZero(), "Ŵ%d(β%d)", i, j)
+		}
+	}
+}
+
+func TestDomainPoints(t *testing.T) {
+	assert := require.New(t)
+
+	const n = 64
+	var shift binarytower.E128
+	shift.SetRandom()
+	domain := NewCosetDomain[binarytower.E128](n, shift)
+
+	// the domain is an affine subspace: s + ωₖ + ωⱼ - s = ω_{k⊕j}
+	points := make(map[binarytower.E128]bool, n)
+	for k := uint64(0); k < n; k++ {
+		points[domain.Point(k)] = true
+		for j := uint64(0); j < n; j++ {
+			p, q := domain.Point(k), domain.Point(j)
+			p.Add(&p, &q).Add(&p, &shift)
+			q = domain.Point(k ^ j)
+			assert.True(p.Equal(&q))
+		}
+	}
+	assert.Equal(n, len(points))
+}
+
+func TestSubfieldDomain(t *testing.T) {
+	assert := require.New(t)
+
+	// the domain of size 2⁸ of E128 is the subfield E8
+	const n = 1 << 8
+	var s8 binarytower.E8
+	s8.SetRandom()
+	var s128 binarytower.E128
+	s128.SetUint64(uint64(s8)) // zero-extension
+
+	d8 := NewCosetDomain[binarytower.E8](n, s8)
+	d128 := NewCosetDomain[binarytower.E128](n, s128)
+	for k := uint64(0); k < n; k++ {
+		assert.Equal(binarytower.E128{uint64(k), 0}, d128.Point(k^uint64(s8)))
+	}
+
+	// an FFT over E8 is the FFT of the zero-extended coordinates over E128
+	a8 := randomVector[binarytower.E8](n)
+	a128 := make([]binarytower.E128, n)
+	for i := range a8 {
+		a128[i].SetUint64(uint64(a8[i]))
+	}
+	d8.FFT(a8)
+	d128.FFT(a128)
+	for i := range a8 {
+		assert.Equal(binarytower.E128{uint64(a8[i]), 0}, a128[i], "FFT mismatch at index %d", i)
+	}
+}
+
+func TestLowDegreeExtension(t *testing.T) {
+	assert := require.New(t)
+
+	// a polynomial interpolated on a small domain can be evaluated on a larger one
+	const n = 16
+	var shift binarytower.E128
+	shift.SetRandom()
+	small, large := NewCosetDomain[binarytower.E128](n, shift), NewCosetDomain[binarytower.E128](4*n, shift)
+
+	evals := randomVector[binarytower.E128](n)
+	coeffs := make([]binarytower.E128, n)
+	copy(coeffs, evals)
+	small.FFTInverse(coeffs)
+
+	// the novel basis doesn't depend on the size of the domain, so the zero-padded coordinates
+	// describe the same polynomial, and the small domain is the beginning of the large one
+	extended := make([]binarytower.E128, 4*n)
+	copy(extended, coeffs)
+	large.FFT(extended)
+	assert.Equal(evals, extended[:n])
+	for i := uint64(0); i < 4*n; i++ {
+		e := Evaluate[binarytower.E128](coeffs, large.Point(i))
+		assert.True(e.Equal(&extended[i]))
+	}
+}
+
+func BenchmarkFFT(b *testing.B) {
+	b.Run("E32", benchmarkFFT[binarytower.E32])
+	b.Run("E128", benchmarkFFT[binarytower.E128])
+}
+
+func benchmarkFFT[T any, PT randomElement[T]](b *testing.B) {
+	const n = 1 << 20
+	domain := NewDomain[T, PT](n)
+	a := randomVector[T, PT](n)
+
+	b.Run("FFT", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			domain.FFT(a)
+		}
+	})
+	b.Run("FFTInverse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			domain.FFTInverse(a)
+		}
+	})
+}
+
+func randomVector[T any, PT randomElement[T]](n int) []T {
+	v := make([]T, n)
+	for i := range v {
+		PT(&v[i]).SetRandom()
+	}
+	return v
+}
@@ -0,0 +1,237 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binarytower
+
+// An element of level k of the tower has 2ᵏ bits; it is a + b⋅Xₖ₋₁, where a (the low half of the
+// bits) and b (the high half) are elements of level k-1 and Xₖ₋₁² = Xₖ₋₂⋅Xₖ₋₁ + 1 (X₋₁ = 1).
+//
+// With m₀ = a⋅c, m₂ = b⋅d and m₁ = (a + b)⋅(c + d), the product is
+//
+//	(a + b⋅Xₖ₋₁)⋅(c + d⋅Xₖ₋₁) = (m₀ + m₂) + (m₁ + m₀ + m₂ + m₂⋅Xₖ₋₂)⋅Xₖ₋₁
+//
+// and the conjugate of Xₖ₋₁ being Xₖ₋₁ + Xₖ₋₂ (their product is 1), the inverse is
+//
+//	(a + b⋅Xₖ₋₁)⁻¹ = ((a + b⋅Xₖ₋₂) + b⋅Xₖ₋₁) / (a⋅(a + b⋅Xₖ₋₂) + b²)
+
+// mulTower returns x⋅y in the level k ≤ 3 of the tower, bit by bit; it is only used to compute the
+// tables of E8
+func mulTower(x, y uint8, k uint) uint8 {
+	if k == 0 {
+		return x & y
+	}
+	h := uint(1) << (k - 1)
+	mask := uint8(1)<<h - 1
+	a, b := x&mask, x>>h
+	c, d := y&mask, y>>h
+	m0 := mulTower(a, c, k-1)
+	m1 := mulTower(a^b, c^d, k-1)
+	m2 := mulTower(b, d, k-1)
+	alpha := uint8(1) << (h / 2) // Xₖ₋₂, or 1 for k = 1
+	return (m0 ^ m2) | (m1^m0^m2^mulTower(m2, alpha, k-1))<<h
+}
+
+var (
+	e8Exp      [2 * 255]uint8 // e8Exp[i] = gⁱ for a generator g of E8*
+	e8Log      [256]uint8     // e8Log[gⁱ] = i
+	e8Inv      [256]uint8     // e8Inv[x] = x⁻¹, e8Inv[0] = 0
+	e8MulAlpha [256]uint8     // e8MulAlpha[x] = x⋅X₂
+
+	e16Exp [2 * 65535]uint16 // e16Exp[i] = gⁱ for a generator g of E16*
+	e16Log [65536]uint16     // e16Log[gⁱ] = i
+)
+
+func init() {
+	// find a generator of the multiplicative group, of order 255 = 3⋅5⋅17
+	var g uint8
+	for g = 2; ; g++ {
+		p, order := g, 1
+		for ; p != 1; order++ {
+			p = mulTower(p, g, 3)
+		}
+		if order == 255 {
+			break
+		}
+	}
+
+	p := uint8(1)
+	for i := 0; i < len(e8Exp); i++ {
+		e8Exp[i] = p
+		if i < 255 {
+			e8Log[p] = uint8(i)
+		}
+		p = mulTower(p, g, 3)
+	}
+	for x := 1; x < 256; x++ {
+		e8Inv[x] = e8Exp[255-int(e8Log[x])]
+	}
+	for x := 0; x < 256; x++ {
+		e8MulAlpha[x] = mulE8(uint8(x), 0x10)
+	}
+
+	// find a generator of E16*, of order 65535 = 3⋅5⋅17⋅257, with the products of E8, then tabulate
+	// its powers
+	var g16 uint16
+	for g16 = 2; ; g16++ {
+		isGenerator := true
+		for _, p := range []int{3, 5, 17, 257} {
+			if expE16(g16, 65535/p) == 1 {
+				isGenerator = false
+				break
+			}
+		}
+		if isGenerator {
+			break
+		}
+	}
+	p16 := uint16(1)
+	for i := 0; i < len(e16Exp); i++ {
+		e16Exp[i] = p16
+		if i < 65535 {
+			e16Log[p16] = uint16(i)
+		}
+		p16 = mulE16Karatsuba(p16, g16)
+	}
+}
+
+// expE16 returns xᵉ, with the products of E8
+func expE16(x uint16, e int) uint16 {
+	res := uint16(1)
+	for ; e != 0; e >>= 1 {
+		if e&1 == 1 {
+			res = mulE16Karatsuba(res, x)
+		}
+		x = mulE16Karatsuba(x, x)
+	}
+	return res
+}
+
+func mulE8(x, y uint8) uint8 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return e8Exp[int(e8Log[x])+int(e8Log[y])]
+}
+
+func squareE8(x uint8) uint8 {
+	return mulE8(x, x)
+}
+
+func mulE16(x, y uint16) uint16 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return e16Exp[int(e16Log[x])+int(e16Log[y])]
+}
+
+// mulE16Karatsuba returns x⋅y with three products in E8; it is used to compute the tables of E16
+func mulE16Karatsuba(x, y uint16) uint16 {
+	a, b := uint8(x), uint8(x>>8)
+	c, d := uint8(y), uint8(y>>8)
+	m0 := mulE8(a, c)
+	m1 := mulE8(a^b, c^d)
+	m2 := mulE8(b, d)
+	return uint16(m0^m2) | uint16(m1^m0^m2^e8MulAlpha[m2])<<8
+}
+
+func squareE16(x uint16) uint16 {
+	// (a + b⋅X₃)² = (a² + b²) + b²⋅X₂⋅X₃
+	a2, b2 := squareE8(uint8(x)), squareE8(uint8(x>>8))
+	return uint16(a2^b2) | uint16(e8MulAlpha[b2])<<8
+}
+
+// mulAlphaE16 returns x⋅X₃
+func mulAlphaE16(x uint16) uint16 {
+	a, b := uint8(x), uint8(x>>8)
+	return uint16(b) | uint16(a^e8MulAlpha[b])<<8
+}
+
+func inverseE16(x uint16) uint16 {
+	if x == 0 {
+		return 0
+	}
+	return e16Exp[65535-int(e16Log[x])]
+}
+
+func mulE32(x, y uint32) uint32 {
+	a, b := uint16(x), uint16(x>>16)
+	c, d := uint16(y), uint16(y>>16)
+	m0 := mulE16(a, c)
+	m1 := mulE16(a^b, c^d)
+	m2 := mulE16(b, d)
+	return uint32(m0^m2) | uint32(m1^m0^m2^mulAlphaE16(m2))<<16
+}
+
+func squareE32(x uint32) uint32 {
+	a2, b2 := squareE16(uint16(x)), squareE16(uint16(x>>16))
+	return uint32(a2^b2) | uint32(mulAlphaE16(b2))<<16
+}
+
+// mulAlphaE32 returns x⋅X₄
+func mulAlphaE32(x uint32) uint32 {
+	a, b := uint16(x), uint16(x>>16)
+	return uint32(b) | uint32(a^mulAlphaE16(b))<<16
+}
+
+func inverseE32(x uint32) uint32 {
+	a, b := uint16(x), uint16(x>>16)
+	t := a ^ mulAlphaE16(b)
+	nInv := inverseE16(mulE16(a, t) ^ squareE16(b))
+	return uint32(mulE16(t, nInv)) | uint32(mulE16(b, nInv))<<16
+}
+
+func mulE64(x, y uint64) uint64 {
+	a, b := uint32(x), uint32(x>>32)
+	c, d := uint32(y), uint32(y>>32)
+	m0 := mulE32(a, c)
+	m1 := mulE32(a^b, c^d)
+	m2 := mulE32(b, d)
+	return uint64(m0^m2) | uint64(m1^m0^m2^mulAlphaE32(m2))<<32
+}
+
+func squareE64(x uint64) uint64 {
+	a2, b2 := squareE32(uint32(x)), squareE32(uint32(x>>32))
+	return uint64(a2^b2) | uint64(mulAlphaE32(b2))<<32
+}
+
+// mulAlphaE64 returns x⋅X₅
+func mulAlphaE64(x uint64) uint64 {
+	a, b := uint32(x), uint32(x>>32)
+	return uint64(b) | uint64(a^mulAlphaE32(b))<<32
+}
+
+func inverseE64(x uint64) uint64 {
+	a, b := uint32(x), uint32(x>>32)
+	t := a ^ mulAlphaE32(b)
+	nInv := inverseE32(mulE32(a, t) ^ squareE32(b))
+	return uint64(mulE32(t, nInv)) | uint64(mulE32(b, nInv))<<32
+}
+
+func mulE128(z, x, y *E128) {
+	m0 := mulE64(x[0], y[0])
+	m1 := mulE64(x[0]^x[1], y[0]^y[1])
+	m2 := mulE64(x[1], y[1])
+	z[0], z[1] = m0^m2, m1^m0^m2^mulAlphaE64(m2)
+}
+
+func squareE128(z, x *E128) {
+	a2, b2 := squareE64(x[0]), squareE64(x[1])
+	z[0], z[1] = a2^b2, mulAlphaE64(b2)
+}
+
+func inverseE128(z, x *E128) {
+	t := x[0] ^ mulAlphaE64(x[1])
+	nInv := inverseE64(mulE64(x[0], t) ^ squareE64(x[1]))
+	z[0], z[1] = mulE64(t, nInv), mulE64(x[1], nInv)
+}
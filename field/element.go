@@ -0,0 +1,108 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package field provides a generic interface to the finite field elements
+// generated by gnark-crypto (fr.Element, fp.Element, goldilocks.Element, ...),
+// and helpers written once over it.
+package field
+
+import (
+	"io"
+	"math/big"
+)
+
+// Element is a constraint satisfied by the pointer type *T of every element
+// generated by field/generator (and goff).
+//
+// Generic code is written over the pair [T any, PT Element[T]], for example:
+//
+//	func Sum[T any, PT Element[T]](a []T) (res T) {
+//		for i := range a {
+//			PT(&res).Add(&res, &a[i])
+//		}
+//		return
+//	}
+//
+// and instantiated with the value type only: Sum[fr.Element](v).
+type Element[T any] interface {
+	*T
+
+	Set(x *T) *T
+	SetZero() *T
+	SetOne() *T
+	SetUint64(v uint64) *T
+	SetInt64(v int64) *T
+	SetBigInt(v *big.Int) *T
+	SetBytes(e []byte) *T
+	SetString(s string) (*T, error)
+	SetRandom() (*T, error)
+	SetRandomFrom(r io.Reader) (*T, error)
+
+	Add(x, y *T) *T
+	Sub(x, y *T) *T
+	Double(x *T) *T
+	Neg(x *T) *T
+	Mul(x, y *T) *T
+	Square(x *T) *T
+	Inverse(x *T) *T
+	Div(x, y *T) *T
+	Exp(x T, k *big.Int) *T
+	Sqrt(x *T) *T
+	Legendre() int
+
+	Equal(x *T) bool
+	Cmp(x *T) int
+	IsZero() bool
+	IsOne() bool
+
+	BigInt(res *big.Int) *big.Int
+	Marshal() []byte
+	String() string
+}
+
+// BatchInvert returns a new slice with every element of a inverted.
+// Uses Montgomery batch inversion trick.
+//
+// if a[i] == 0, returns result[i] = a[i]
+func BatchInvert[T any, PT Element[T]](a []T) []T {
+	res := make([]T, len(a))
+	if len(a) == 0 {
+		return res
+	}
+
+	zeroes := make([]bool, len(a))
+	var accumulator T
+	PT(&accumulator).SetOne()
+
+	for i := 0; i < len(a); i++ {
+		if PT(&a[i]).IsZero() {
+			zeroes[i] = true
+			continue
+		}
+		res[i] = accumulator
+		PT(&accumulator).Mul(&accumulator, &a[i])
+	}
+
+	PT(&accumulator).Inverse(&accumulator)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		if zeroes[i] {
+			continue
+		}
+		PT(&res[i]).Mul(&res[i], &accumulator)
+		PT(&accumulator).Mul(&accumulator, &a[i])
+	}
+
+	return res
+}
@@ -0,0 +1,124 @@
+package field_test
+
+import (
+	"math/big"
+	"testing"
+
+	bls12381fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	secp256k1fp "github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
+	"github.com/consensys/gnark-crypto/field"
+	"github.com/consensys/gnark-crypto/field/babybear"
+	"github.com/consensys/gnark-crypto/field/goldilocks"
+	"github.com/consensys/gnark-crypto/field/m31"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenericHelpers(t *testing.T) {
+	t.Run("bn254/fr", testGenericHelpers[fr.Element])
+	t.Run("bls12-381/fp", testGenericHelpers[bls12381fp.Element])
+	t.Run("goldilocks", testGenericHelpers[goldilocks.Element])
+	t.Run("babybear", testGenericHelpers[babybear.Element])
+	t.Run("m31", testGenericHelpers[m31.Element])
+	t.Run("secp256k1/fp", testGenericHelpers[secp256k1fp.Element])
+}
+
+func TestSmallFieldsRepresentation(t *testing.T) {
+	assert := require.New(t)
+
+	// babybear and m31 elements are stored on a uint32 and encoded on 4 bytes
+	assert.Equal(4, babybear.Bytes)
+	assert.Equal(4, m31.Bytes)
+	a, b := babybear.NewElement(42), m31.NewElement(42)
+	assert.Equal(4, len(a.Marshal()))
+	assert.Equal(4, len(b.Marshal()))
+
+	// babybear is in Montgomery form with R = 2³²
+	r := new(big.Int).Lsh(big.NewInt(1), 32)
+	r.Mod(r, babybear.Modulus())
+	assert.Equal(uint32(r.Uint64()), babybear.One()[0])
+
+	// m31 is in regular form
+	assert.Equal(m31.Element{42}, m31.NewElement(42))
+	assert.Equal(m31.Element{0}, m31.NewElement(1<<31-1))
+}
+
+func testGenericHelpers[T any, PT field.Element[T]](t *testing.T) {
+	assert := require.New(t)
+
+	const n = 17
+	a, b := randomVector[T, PT](n), randomVector[T, PT](n)
+	var c T
+	PT(&c).SetRandom()
+
+	// vector ops against element ops
+	res := make([]T, n)
+	var expected, sum, innerProduct T
+	field.VectorAdd[T, PT](res, a, b)
+	for i := range res {
+		PT(&expected).Add(&a[i], &b[i])
+		assert.True(PT(&res[i]).Equal(&expected), "VectorAdd mismatch at index %d", i)
+	}
+	field.VectorSub[T, PT](res, a, b)
+	for i := range res {
+		PT(&expected).Sub(&a[i], &b[i])
+		assert.True(PT(&res[i]).Equal(&expected), "VectorSub mismatch at index %d", i)
+	}
+	field.VectorMul[T, PT](res, a, b)
+	for i := range res {
+		PT(&expected).Mul(&a[i], &b[i])
+		assert.True(PT(&res[i]).Equal(&expected), "VectorMul mismatch at index %d", i)
+		PT(&innerProduct).Add(&innerProduct, &expected)
+		PT(&sum).Add(&sum, &a[i])
+	}
+	field.VectorScalarMul[T, PT](res, a, &c)
+	for i := range res {
+		PT(&expected).Mul(&a[i], &c)
+		assert.True(PT(&res[i]).Equal(&expected), "VectorScalarMul mismatch at index %d", i)
+	}
+	s := field.VectorSum[T, PT](a)
+	assert.True(PT(&s).Equal(&sum), "VectorSum mismatch")
+	ip := field.VectorInnerProduct[T, PT](a, b)
+	assert.True(PT(&ip).Equal(&innerProduct), "VectorInnerProduct mismatch")
+	assert.Panics(func() { field.VectorAdd[T, PT](res, a, b[:n-1]) })
+
+	// polynomial evaluation against the naive sum of aᵢ⋅cⁱ
+	var naive, power, tmp T
+	PT(&power).SetOne()
+	for i := range a {
+		PT(&tmp).Mul(&a[i], &power)
+		PT(&naive).Add(&naive, &tmp)
+		PT(&power).Mul(&power, &c)
+	}
+	eval := field.PolynomialEval[T, PT](a, &c)
+	assert.True(PT(&eval).Equal(&naive), "PolynomialEval mismatch")
+
+	// batch inversion, with a zero
+	PT(&a[3]).SetZero()
+	aInv := field.BatchInvert[T, PT](a)
+	for i := range a {
+		if i == 3 {
+			assert.True(PT(&aInv[i]).IsZero(), "0⁻¹ != 0")
+			continue
+		}
+		PT(&tmp).Mul(&a[i], &aInv[i])
+		assert.True(PT(&tmp).IsOne(), "x * x⁻¹ != 1 at index %d", i)
+	}
+	assert.Equal(0, len(field.BatchInvert[T, PT](nil)))
+
+	// a few element methods through the interface
+	var x, y T
+	PT(&x).SetBigInt(big.NewInt(-1))
+	PT(&y).SetInt64(-1)
+	assert.True(PT(&x).Equal(&y))
+	PT(&y).SetBytes(PT(&x).Marshal())
+	assert.True(PT(&x).Equal(&y))
+}
+
+func randomVector[T any, PT field.Element[T]](n int) []T {
+	v := make([]T, n)
+	for i := range v {
+		PT(&v[i]).SetRandom()
+	}
+	return v
+}
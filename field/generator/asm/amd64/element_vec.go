@@ -0,0 +1,189 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amd64
+
+import (
+	"fmt"
+
+	"github.com/consensys/bavard/amd64"
+)
+
+// addVec res = a + b
+//
+//	func addVec(res, a, b *{{.ElementName}}, n uint64) {
+//		for i := 0; i < n; i++ {
+//			res[i].Add(&a[i], &b[i])
+//		}
+//	}
+//
+// addVec and subVec have no AVX-512 version: the vector units have no carry flag, so
+// each 64-bit limb would need a compare and a masked add to propagate its carry (and
+// the elements would have to be transposed into limb vectors and back), which is more
+// work per element than the scalar loop; on 4-word elements, the latter already takes
+// about 5ns per element (bn254 fr, 2¹⁶ elements).
+func (f *FFAmd64) generateAddVec() {
+	f.Comment("addVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] + b[0...n]")
+
+	const argSize = 4 * 8
+	stackSize := f.StackSize(4+f.NbWords*2, 0, 0)
+	registers := f.FnHeader("addVec", stackSize, argSize)
+	defer f.AssertCleanStack(stackSize, 0)
+
+	// registers
+	addrA := f.Pop(&registers)
+	addrB := f.Pop(&registers)
+	addrRes := f.Pop(&registers)
+	n := f.Pop(&registers)
+	t := f.PopN(&registers)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	f.MOVQ("res+0(FP)", addrRes)
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", n)
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done, "n == 0, we are done")
+
+	// t = a[i] + b[i]
+	f.Mov(addrA, t)
+	f.Add(addrB, t)
+
+	// reduce t
+	f.Reduce(&registers, t)
+
+	// res[i] = t
+	f.Mov(t, addrRes)
+
+	// increment pointers to visit next element
+	f.incrementPointers(addrA, addrB, addrRes)
+	f.DECQ(n, "decrement n")
+	f.JMP(loop)
+
+	f.LABEL(done)
+
+	f.RET()
+
+	f.Push(&registers, t...)
+	f.Push(&registers, addrA, addrB, addrRes, n)
+}
+
+// subVec res = a - b
+//
+//	func subVec(res, a, b *{{.ElementName}}, n uint64) {
+//		for i := 0; i < n; i++ {
+//			res[i].Sub(&a[i], &b[i])
+//		}
+//	}
+func (f *FFAmd64) generateSubVec() {
+	f.Comment("subVec(res, a, b *Element, n uint64) res[0...n] = a[0...n] - b[0...n]")
+
+	// up to 4 words, q is added back without branching (the borrow of random inputs
+	// is unpredictable); larger elements don't leave enough registers for it
+	branchless := f.NbWords <= 4
+	nbRegisters := 4 + f.NbWords
+	if branchless {
+		nbRegisters += f.NbWords + 1
+	}
+
+	const argSize = 4 * 8
+	stackSize := f.StackSize(nbRegisters, 0, 0)
+	registers := f.FnHeader("subVec", stackSize, argSize)
+	defer f.AssertCleanStack(stackSize, 0)
+
+	// registers
+	addrA := f.Pop(&registers)
+	addrB := f.Pop(&registers)
+	addrRes := f.Pop(&registers)
+	n := f.Pop(&registers)
+	t := f.PopN(&registers)
+
+	loop := f.NewLabel()
+	done := f.NewLabel()
+
+	f.MOVQ("res+0(FP)", addrRes)
+	f.MOVQ("a+8(FP)", addrA)
+	f.MOVQ("b+16(FP)", addrB)
+	f.MOVQ("n+24(FP)", n)
+
+	f.LABEL(loop)
+
+	f.TESTQ(n, n)
+	f.JEQ(done, "n == 0, we are done")
+
+	if branchless {
+		zero := f.Pop(&registers)
+		q := f.PopN(&registers)
+		f.XORQ(zero, zero)
+
+		// t = a[i] - b[i]
+		f.Mov(addrA, t)
+		f.Sub(addrB, t)
+
+		// q = q if we borrowed, 0 otherwise, added back to t
+		f.Mov(f.Q, q)
+		for i := 0; i < f.NbWords; i++ {
+			f.CMOVQCC(zero, q[i])
+		}
+		f.Add(q, t)
+
+		f.Push(&registers, q...)
+		f.Push(&registers, zero)
+	} else {
+		noReduce := f.NewLabel()
+
+		// t = a[i] - b[i]
+		f.Mov(addrA, t)
+		f.Sub(addrB, t)
+
+		// if we borrowed, add q back to t
+		f.JCC(noReduce)
+		for i := 0; i < f.NbWords; i++ {
+			if i == 0 {
+				f.ADDQ(f.qAt(i), t[i])
+			} else {
+				f.ADCQ(f.qAt(i), t[i])
+			}
+		}
+		f.LABEL(noReduce)
+	}
+
+	// res[i] = t
+	f.Mov(t, addrRes)
+
+	// increment pointers to visit next element
+	f.incrementPointers(addrA, addrB, addrRes)
+	f.DECQ(n, "decrement n")
+	f.JMP(loop)
+
+	f.LABEL(done)
+
+	f.RET()
+
+	f.Push(&registers, t...)
+	f.Push(&registers, addrA, addrB, addrRes, n)
+}
+
+// incrementPointers advances each pointer to the next element in memory.
+func (f *FFAmd64) incrementPointers(addrs ...amd64.Register) {
+	offset := fmt.Sprintf("$%d", f.NbWords*8)
+	for _, addr := range addrs {
+		f.ADDQ(offset, addr)
+	}
+}
@@ -39,6 +39,7 @@ type FieldConfig struct {
 	Modulus                   string
 	ModulusHex                string
 	NbWords                   int
+	Word                      Word // the words the elements are stored on
 	NbBits                    int
 	NbBytes                   int
 	NbWordsLastIndex          int
@@ -57,6 +58,8 @@ type FieldConfig struct {
 	LegendreExponent          string // big.Int to base16 string
 	NoCarry                   bool
 	NoCarrySquare             bool // used if NoCarry is set, but some op may overflow in square optimization
+	F31                       bool // q < 2³¹: elements are stored on a single uint32, products fit on a uint64
+	Mersenne31                bool // q = 2³¹ - 1: elements are not in Montgomery form, products are reduced with shifts and masks
 	SqrtQ3Mod4                bool
 	SqrtAtkin                 bool
 	SqrtTonelliShanks         bool
@@ -74,6 +77,22 @@ type FieldConfig struct {
 	UseAddChain               bool
 }
 
+// Word describes the machine words the field elements are stored on
+type Word struct {
+	BitSize   int    // 32 or 64
+	ByteSize  int    // 4 or 8
+	TypeLower string // uint32 or uint64
+	TypeUpper string // Uint32 or Uint64
+	Add       string // bits.Add32 or bits.Add64
+	Sub       string // bits.Sub32 or bits.Sub64
+	Len       string // bits.Len32 or bits.Len64
+}
+
+var (
+	word32 = Word{BitSize: 32, ByteSize: 4, TypeLower: "uint32", TypeUpper: "Uint32", Add: "bits.Add32", Sub: "bits.Sub32", Len: "bits.Len32"}
+	word64 = Word{BitSize: 64, ByteSize: 8, TypeLower: "uint64", TypeUpper: "Uint64", Add: "bits.Add64", Sub: "bits.Sub64", Len: "bits.Len64"}
+)
+
 // NewFieldConfig returns a data structure with needed information to generate apis for field element
 //
 // See field/generator package
@@ -96,7 +115,21 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 	// pre compute field constants
 	F.NbBits = bModulus.BitLen()
 	F.NbWords = len(bModulus.Bits())
-	F.NbBytes = F.NbWords * 8 // (F.NbBits + 7) / 8
+
+	// small moduli are stored on a single uint32 and get a dedicated multiplication, see the "mul_f31" template;
+	// q = 2³¹ - 1 is reduced with shifts and masks, without the Montgomery form (rBits = 0, R = 1)
+	F.F31 = F.NbBits <= 31
+	F.Mersenne31 = F.F31 && bModulus.Uint64() == 1<<31-1
+	F.Word = word64
+	if F.F31 {
+		F.Word = word32
+	}
+	rBits := uint(F.NbWords * F.Word.BitSize)
+	if F.Mersenne31 {
+		rBits = 0
+	}
+
+	F.NbBytes = F.NbWords * F.Word.ByteSize // (F.NbBits + 7) / 8
 
 	F.NbWordsLastIndex = F.NbWords - 1
 
@@ -110,7 +143,7 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 
 	//  setting qInverse
 	_r := big.NewInt(1)
-	_r.Lsh(_r, uint(F.NbWords)*64)
+	_r.Lsh(_r, uint(F.NbWords*F.Word.BitSize))
 	_rInv := big.NewInt(1)
 	_qInv := big.NewInt(0)
 	extendedEuclideanAlgo(_r, &bModulus, _rInv, _qInv)
@@ -139,19 +172,19 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 
 	// rsquare
 	_rSquare := big.NewInt(2)
-	exponent := big.NewInt(int64(F.NbWords) * 64 * 2)
+	exponent := big.NewInt(int64(rBits) * 2)
 	_rSquare.Exp(_rSquare, exponent, &bModulus)
 	F.RSquare = toUint64Slice(_rSquare, F.NbWords)
 
 	var one big.Int
 	one.SetUint64(1)
-	one.Lsh(&one, uint(F.NbWords)*64).Mod(&one, &bModulus)
+	one.Lsh(&one, rBits).Mod(&one, &bModulus)
 	F.One = toUint64Slice(&one, F.NbWords)
 
 	{
 		var n big.Int
 		n.SetUint64(13)
-		n.Lsh(&n, uint(F.NbWords)*64).Mod(&n, &bModulus)
+		n.Lsh(&n, rBits).Mod(&n, &bModulus)
 		F.Thirteen = toUint64Slice(&n, F.NbWords)
 	}
 
@@ -240,7 +273,7 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 			var g big.Int
 			g.Exp(&nonResidue, &s, &bModulus)
 			// store g in montgomery form
-			g.Lsh(&g, uint(F.NbWords)*64).Mod(&g, &bModulus)
+			g.Lsh(&g, rBits).Mod(&g, &bModulus)
 			F.SqrtG = toUint64Slice(&g, F.NbWords)
 
 			// store non residue in montgomery form
@@ -324,7 +357,7 @@ func (f *FieldConfig) StringToMont(str string) big.Int {
 
 func (f *FieldConfig) ToMont(nonMont big.Int) big.Int {
 	var mont big.Int
-	mont.Lsh(&nonMont, uint(f.NbWords)*64)
+	mont.Lsh(&nonMont, f.rBits())
 	mont.Mod(&mont, f.ModulusBig)
 	return mont
 }
@@ -335,14 +368,22 @@ func (f *FieldConfig) FromMont(nonMont *big.Int, mont *big.Int) *FieldConfig {
 		nonMont.SetInt64(0)
 		return f
 	}
-	f.halve(nonMont, mont)
-	for i := 1; i < f.NbWords*64; i++ {
+	nonMont.Set(mont)
+	for i := uint(0); i < f.rBits(); i++ {
 		f.halve(nonMont, nonMont)
 	}
 
 	return f
 }
 
+// rBits returns log₂ of the Montgomery constant R
+func (f *FieldConfig) rBits() uint {
+	if f.Mersenne31 {
+		return 0
+	}
+	return uint(f.NbWords * f.Word.BitSize)
+}
+
 func (f *FieldConfig) Exp(res *big.Int, x *big.Int, pow *big.Int) *FieldConfig {
 	res.SetInt64(1)
 
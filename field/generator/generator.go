@@ -34,6 +34,7 @@ func GenerateFF(F *config.FieldConfig, outputDir string) error {
 		element.MulNoCarry,
 		element.Sqrt,
 		element.Inverse,
+		element.ConstantTime,
 		element.BigNum,
 	}
 
@@ -218,6 +219,32 @@ func GenerateFF(F *config.FieldConfig, outputDir string) error {
 		}
 	}
 
+	{
+		// generate vector_amd64.go and vector_purego.go
+		asmVector := F.ASM && F.NbWords <= amd64.SmallModulus
+		pathSrcAMD64 := filepath.Join(outputDir, "vector_amd64.go")
+		pathSrcPureGo := filepath.Join(outputDir, "vector_purego.go")
+		if asmVector {
+			bavardOptsCpy := make([]func(*bavard.Bavard) error, len(bavardOpts))
+			copy(bavardOptsCpy, bavardOpts)
+			bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag("!purego"))
+			if err := bavard.GenerateFromString(pathSrcAMD64, []string{element.VectorOpsAMD64}, F, bavardOptsCpy...); err != nil {
+				return err
+			}
+		} else {
+			_ = os.Remove(pathSrcAMD64)
+		}
+
+		bavardOptsCpy := make([]func(*bavard.Bavard) error, len(bavardOpts))
+		copy(bavardOptsCpy, bavardOpts)
+		if asmVector {
+			bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag("!amd64 purego"))
+		}
+		if err := bavard.GenerateFromString(pathSrcPureGo, []string{element.VectorOpsPureGo}, F, bavardOptsCpy...); err != nil {
+			return err
+		}
+	}
+
 	{
 		// generate doc.go
 		src := []string{
@@ -11,7 +11,9 @@ import (
 	"strconv"
 	"errors"
 	"reflect"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/consensys/gnark-crypto/field/hash"
 	"github.com/consensys/gnark-crypto/field/pool"
@@ -29,10 +31,10 @@ import (
 // Warning
 //
 // This code has not been audited and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
-type {{.ElementName}} [{{.NbWords}}]uint64
+type {{.ElementName}} [{{.NbWords}}]{{.Word.TypeLower}}
 
 const (
-	Limbs = {{.NbWords}} 	// number of 64 bits words needed to represent a {{.ElementName}}
+	Limbs = {{.NbWords}} 	// number of {{.Word.BitSize}} bits words needed to represent a {{.ElementName}}
 	Bits = {{.NbBits}} 		// number of bits needed to represent a {{.ElementName}}
 	Bytes = {{.NbBytes}} 	// number of bytes needed to represent a {{.ElementName}}
 )
@@ -41,9 +43,9 @@ const (
 // Field modulus q
 const (
 {{- range $i := $.NbWordsIndexesFull}}
-	q{{$i}} uint64 = {{index $.Q $i}}
+	q{{$i}} {{$.Word.TypeLower}} = {{index $.Q $i}}
 	{{- if eq $.NbWords 1}}
-		q uint64 = q0
+		q {{$.Word.TypeLower}} = q0
 	{{- end}}
 {{- end}}
 )
@@ -65,7 +67,7 @@ func Modulus() *big.Int {
 
 // q + r'.r = 1, i.e., qInvNeg = - q⁻¹ mod r
 // used for Montgomery reduction
-const qInvNeg uint64 = {{index .QInverse 0}}
+const qInvNeg {{.Word.TypeLower}} = {{index .QInverse 0}}
 
 func init() {
 	_modulus.SetString("{{.ModulusHex}}", 16)
@@ -77,7 +79,11 @@ func init() {
 // 		var v {{.ElementName}}
 // 		v.SetUint64(...)
 func New{{.ElementName}}(v uint64) {{.ElementName}} {
+	{{- if .F31}}
+	z := {{.ElementName}}{uint32(v % uint64(q))}
+	{{- else}}
 	z := {{.ElementName}}{v}
+	{{- end}}
 	z.Mul(&z, &rSquare)
 	return z
 }
@@ -85,7 +91,12 @@ func New{{.ElementName}}(v uint64) {{.ElementName}} {
 // SetUint64 sets z to v and returns z
 func (z *{{.ElementName}}) SetUint64(v uint64) *{{.ElementName}} {
 	//  sets z LSB to v (non-Montgomery form) and convert z to Montgomery form
+	{{- if .F31}}
+	// elements are stored on 32 bits
+	*z = {{.ElementName}}{uint32(v % uint64(q))}
+	{{- else}}
 	*z = {{.ElementName}}{v}
+	{{- end}}
 	return z.Mul(z, &rSquare) // z.toMont()
 }
 
@@ -204,7 +215,11 @@ func (z *{{.ElementName}}) Equal(x *{{.ElementName}}) bool {
 
 // NotEqual returns 0 if and only if z == x; constant-time
 func (z *{{.ElementName}}) NotEqual(x *{{.ElementName}}) uint64 {
+{{- if .F31}}
+	return uint64(z[0] ^ x[0])
+{{- else}}
 return {{- range $i :=  reverse .NbWordsIndexesNoZero}}(z[{{$i}}] ^ x[{{$i}}]) | {{end}}(z[0] ^ x[0])
+{{- end}}
 }
 
 // IsZero returns z == 0
@@ -234,7 +249,11 @@ func (z *{{.ElementName}}) IsUint64() bool {
 
 // Uint64 returns the uint64 representation of x. If x cannot be represented in a uint64, the result is undefined.
 func (z *{{.ElementName}}) Uint64() uint64 {
+	{{- if .F31}}
+	return uint64(z.Bits()[0])
+	{{- else}}
 	return z.Bits()[0]
+	{{- end}}
 }
 
 // FitsOnOneWord reports whether z words (except the least significant word) are 0
@@ -276,10 +295,10 @@ func (z *{{.ElementName}}) LexicographicallyLargest() bool {
 
 	_z := z.Bits()
 
-	var b uint64
-	_, b = bits.Sub64(_z[0], {{index .QMinusOneHalvedP 0}}, 0)
+	var b {{.Word.TypeLower}}
+	_, b = {{.Word.Sub}}(_z[0], {{index .QMinusOneHalvedP 0}}, 0)
 	{{- range $i := .NbWordsIndexesNoZero}}
-		_, b = bits.Sub64(_z[{{$i}}], {{index $.QMinusOneHalvedP $i}}, b)
+		_, b = {{$.Word.Sub}}(_z[{{$i}}], {{index $.QMinusOneHalvedP $i}}, b)
 	{{- end}}
 
 	return b == 0
@@ -290,11 +309,20 @@ func (z *{{.ElementName}}) LexicographicallyLargest() bool {
 // This might error only if reading from crypto/rand.Reader errors,
 // in which case, value of z is undefined.
 func (z *{{.ElementName}}) SetRandom() (*{{.ElementName}}, error) {
+	return z.SetRandomFrom(rand.Reader)
+}
+
+// SetRandomFrom sets z to a uniform random value in [0, q), reading randomness from r.
+// Candidates larger than q are rejected, so the result is unbiased if r is.
+//
+// This might error only if reading from r errors,
+// in which case, value of z is undefined.
+func (z *{{.ElementName}}) SetRandomFrom(r io.Reader) (*{{.ElementName}}, error) {
 	// this code is generated for all modulus
 	// and derived from go/src/crypto/rand/util.go
 
-	// l is number of limbs * 8; the number of bytes needed to reconstruct {{.NbWords}} uint64
-	const l = {{mul 8 .NbWords}}
+	// l is number of limbs * {{.Word.ByteSize}}; the number of bytes needed to reconstruct {{.NbWords}} {{.Word.TypeLower}}
+	const l = {{mul .Word.ByteSize .NbWords}}
 
 	// bitLen is the maximum bit length needed to encode a value < q.
 	const bitLen = {{.NbBits}}
@@ -312,7 +340,7 @@ func (z *{{.ElementName}}) SetRandom() (*{{.ElementName}}, error) {
 
 	for {
 		// note that bytes[k:l] is always 0
-		if _, err := io.ReadFull(rand.Reader, bytes[:k]); err != nil {
+		if _, err := io.ReadFull(r, bytes[:k]); err != nil {
 			return nil, err
 		}
 
@@ -322,7 +350,7 @@ func (z *{{.ElementName}}) SetRandom() (*{{.ElementName}}, error) {
 
 		{{- range $i :=  .NbWordsIndexesFull}}
 			{{- $k := add $i 1}}
-			z[{{$i}}] = binary.LittleEndian.Uint64(bytes[{{mul $i 8}}:{{mul $k 8}}])
+			z[{{$i}}] = binary.LittleEndian.{{$.Word.TypeUpper}}(bytes[{{mul $i $.Word.ByteSize}}:{{mul $k $.Word.ByteSize}}])
 		{{- end}}
 
 		if !z.smallerThanModulus() {
@@ -354,7 +382,7 @@ func One() {{.ElementName}} {
 // Halve sets z to z / 2 (mod q)
 func (z *{{.ElementName}}) Halve()  {
 	{{- if not (and (eq .NbWords 1) (.NoCarry))}}
-		var carry uint64
+		var carry {{.Word.TypeLower}}
 	{{- end}}
 
 	if z[0]&1 == 1 {
@@ -366,7 +394,7 @@ func (z *{{.ElementName}}) Halve()  {
 		if carry != 0 {
 			// when we added q, the result was larger than our available limbs
 			// when we shift right, we need to set the highest bit
-			z[{{.NbWordsLastIndex}}] |= (1 << 63)
+			z[{{.NbWordsLastIndex}}] |= (1 << {{sub .Word.BitSize 1}})
 		}
 	{{end}}
 }
@@ -376,7 +404,7 @@ func (z *{{.ElementName}}) Halve()  {
 	{{- range $i := $.all.NbWordsIndexesFull }}
 		{{- $carryIn := ne $i 0}}
 		{{- $carryOut := or (ne $i $.all.NbWordsLastIndex) (and (eq $i $.all.NbWordsLastIndex) (not $.all.NoCarry))}}
-		{{$.V1}}[{{$i}}], {{- if $carryOut}}carry{{- else}}_{{- end}} = bits.Add64({{$.V1}}[{{$i}}], q{{$i}}, {{- if $carryIn}}carry{{- else}}0{{- end}})
+		{{$.V1}}[{{$i}}], {{- if $carryOut}}carry{{- else}}_{{- end}} = {{$.all.Word.Add}}({{$.V1}}[{{$i}}], q{{$i}}, {{- if $carryIn}}carry{{- else}}0{{- end}})
 	{{- end}}
 {{ end }}
 
@@ -393,11 +421,11 @@ func (z *{{.ElementName}}) fromMont() *{{.ElementName}} {
 func (z *{{.ElementName}}) Add( x, y *{{.ElementName}}) *{{.ElementName}} {
 	{{ $hasCarry := or (not $.NoCarry) (gt $.NbWords 1)}}
 	{{- if $hasCarry}}
-		var carry uint64
+		var carry {{$.Word.TypeLower}}
 	{{- end}}
 	{{- range $i := iterate 0 $.NbWords}}
 		{{- $hasCarry := or (not $.NoCarry) (lt $i $.NbWordsLastIndex)}}
-		z[{{$i}}], {{- if $hasCarry}}carry{{- else}}_{{- end}} = bits.Add64(x[{{$i}}], y[{{$i}}], {{- if eq $i 0}}0{{- else}}carry{{- end}})
+		z[{{$i}}], {{- if $hasCarry}}carry{{- else}}_{{- end}} = {{$.Word.Add}}(x[{{$i}}], y[{{$i}}], {{- if eq $i 0}}0{{- else}}carry{{- end}})
 	{{- end}}
 
 	{{- if eq $.NbWords 1}}
@@ -409,11 +437,11 @@ func (z *{{.ElementName}}) Add( x, y *{{.ElementName}}) *{{.ElementName}} {
 			// if we overflowed the last addition, z >= q
 			// if z >= q, z = z - q
 			if carry != 0 {
-				var b uint64
+				var b {{$.Word.TypeLower}}
 				// we overflowed, so z >= q
 				{{- range $i := iterate 0 $.NbWords}}
 					{{- $hasBorrow := lt $i $.NbWordsLastIndex}}
-					z[{{$i}}], {{- if $hasBorrow}}b{{- else}}_{{- end}} = bits.Sub64(z[{{$i}}], q{{$i}}, {{- if eq $i 0}}0{{- else}}b{{- end}})
+					z[{{$i}}], {{- if $hasBorrow}}b{{- else}}_{{- end}} = {{$.Word.Sub}}(z[{{$i}}], q{{$i}}, {{- if eq $i 0}}0{{- else}}b{{- end}})
 				{{- end}}
 				return z
 			}
@@ -427,7 +455,7 @@ func (z *{{.ElementName}}) Add( x, y *{{.ElementName}}) *{{.ElementName}} {
 // Double z = x + x (mod q), aka Lsh 1
 func (z *{{.ElementName}}) Double( x *{{.ElementName}}) *{{.ElementName}} {
 	{{- if eq .NbWords 1}}
-	if x[0] & (1 << 63) == (1 << 63) {
+	if x[0] & (1 << {{sub .Word.BitSize 1}}) == (1 << {{sub .Word.BitSize 1}}) {
 		// if highest bit is set, then we have a carry to x + x, we shift and subtract q
 		z[0] = (x[0] << 1) - q
 	} else {
@@ -440,21 +468,21 @@ func (z *{{.ElementName}}) Double( x *{{.ElementName}}) *{{.ElementName}} {
 	{{- else}}
 	{{ $hasCarry := or (not $.NoCarry) (gt $.NbWords 1)}}
 	{{- if $hasCarry}}
-		var carry uint64
+		var carry {{$.Word.TypeLower}}
 	{{- end}}
 	{{- range $i := iterate 0 $.NbWords}}
 		{{- $hasCarry := or (not $.NoCarry) (lt $i $.NbWordsLastIndex)}}
-		z[{{$i}}], {{- if $hasCarry}}carry{{- else}}_{{- end}} = bits.Add64(x[{{$i}}], x[{{$i}}], {{- if eq $i 0}}0{{- else}}carry{{- end}})
+		z[{{$i}}], {{- if $hasCarry}}carry{{- else}}_{{- end}} = {{$.Word.Add}}(x[{{$i}}], x[{{$i}}], {{- if eq $i 0}}0{{- else}}carry{{- end}})
 	{{- end}}
 	{{- if not .NoCarry}}
 		// if we overflowed the last addition, z >= q
 		// if z >= q, z = z - q
 		if carry != 0 {
-			var b uint64
+			var b {{$.Word.TypeLower}}
 			// we overflowed, so z >= q
 			{{- range $i := iterate 0 $.NbWords}}
 				{{- $hasBorrow := lt $i $.NbWordsLastIndex}}
-				z[{{$i}}], {{- if $hasBorrow}}b{{- else}}_{{- end}} = bits.Sub64(z[{{$i}}], q{{$i}}, {{- if eq $i 0}}0{{- else}}b{{- end}})
+				z[{{$i}}], {{- if $hasBorrow}}b{{- else}}_{{- end}} = {{$.Word.Sub}}(z[{{$i}}], q{{$i}}, {{- if eq $i 0}}0{{- else}}b{{- end}})
 			{{- end}}
 			return z
 		}
@@ -468,10 +496,10 @@ func (z *{{.ElementName}}) Double( x *{{.ElementName}}) *{{.ElementName}} {
 
 // Sub z = x - y (mod q)
 func (z *{{.ElementName}}) Sub( x, y *{{.ElementName}}) *{{.ElementName}} {
-	var b uint64
-	z[0], b = bits.Sub64(x[0], y[0], 0)
+	var b {{.Word.TypeLower}}
+	z[0], b = {{.Word.Sub}}(x[0], y[0], 0)
 	{{- range $i := .NbWordsIndexesNoZero}}
-		z[{{$i}}], b = bits.Sub64(x[{{$i}}], y[{{$i}}], b)
+		z[{{$i}}], b = {{$.Word.Sub}}(x[{{$i}}], y[{{$i}}], b)
 	{{- end}}
 	if b != 0 {
 		{{- if eq .NbWords 1}}
@@ -516,7 +544,7 @@ func (z *{{.ElementName}}) Neg( x *{{.ElementName}}) *{{.ElementName}} {
 // Select is a constant-time conditional move.
 // If c=0, z = x0. Else z = x1
 func (z *{{.ElementName}}) Select(c int, x0 *{{.ElementName}}, x1 *{{.ElementName}}) *{{.ElementName}} {
-	cC := uint64( (int64(c) | -int64(c)) >> 63 )	// "canonicized" into: 0 if c=0, -1 otherwise
+	cC := {{.Word.TypeLower}}( (int64(c) | -int64(c)) >> 63 )	// "canonicized" into: 0 if c=0, -1 otherwise
 	{{- range $i := .NbWordsIndexesFull }}
 	z[{{$i}}] = x0[{{$i}}] ^ cC & (x0[{{$i}}] ^ x1[{{$i}}])
 	{{- end}}
@@ -527,13 +555,23 @@ func (z *{{.ElementName}}) Select(c int, x0 *{{.ElementName}}, x1 *{{.ElementNam
 // it is a fallback solution on x86 when ADX instruction set is not available
 // and is used for testing purposes.
 func _mulGeneric(z,x,y *{{.ElementName}}) {
+	{{- if .F31}}
+	{{ template "mul_f31" dict "all" . "V1" "x" "V2" "y" }}
+	{{- else}}
 	{{ mul_doc false }}
 	{{ template "mul_cios" dict "all" . "V1" "x" "V2" "y"}}
 	{{ template "reduce"  . }}
+	{{- end}}
 }
 
 
 func _fromMontGeneric(z *{{.ElementName}}) {
+	{{- if .Mersenne31}}
+	// elements are not stored in Montgomery form, there is nothing to do
+	{{- else if .F31}}
+	// z = z * 1, see Mul for algorithm documentation
+	z.Mul(z, &{{.ElementName}}{1})
+	{{- else}}
 	// the following lines implement z = z * 1
 	// with a modified CIOS montgomery multiplication
 	// see Mul for algorithm documentation
@@ -550,6 +588,7 @@ func _fromMontGeneric(z *{{.ElementName}}) {
 	{{- end}}
 
 	{{ template "reduce" .}}
+	{{- end}}
 }
 
 func _reduceGeneric(z *{{.ElementName}})  {
@@ -557,19 +596,122 @@ func _reduceGeneric(z *{{.ElementName}})  {
 }
 
 // BatchInvert returns a new slice with every element inverted.
-// Uses Montgomery batch inversion trick
+// Uses Montgomery batch inversion trick; large inputs are split in chunks that are
+// processed concurrently.
+//
+// Zero elements are mapped to zero, see BatchInvertReportZeroes to detect them.
 func BatchInvert(a []{{.ElementName}}) []{{.ElementName}} {
 	res := make([]{{.ElementName}}, len(a))
 	if len(a) == 0 {
 		return res
 	}
 
-	zeroes := make([]bool, len(a))
+	batchInvertParallel(len(a), batchInvertNbChunks(len(a)), func(_, start, end int) {
+		batchInvert(res[start:end], a[start:end])
+	})
+
+	return res
+}
+
+// BatchInvertReportZeroes returns a new slice with every element inverted, and the
+// indices (in increasing order) of the elements of a which are zero and therefore
+// not invertible. The corresponding entries of the returned slice are set to zero.
+func BatchInvertReportZeroes(a []{{.ElementName}}) (res []{{.ElementName}}, zeroes []int) {
+	res = make([]{{.ElementName}}, len(a))
+	if len(a) == 0 {
+		return
+	}
+
+	nbChunks := batchInvertNbChunks(len(a))
+	chunkZeroes := make([][]int, nbChunks)
+	batchInvertParallel(len(a), nbChunks, func(chunk, start, end int) {
+		batchInvert(res[start:end], a[start:end])
+		for i := start; i < end; i++ {
+			if a[i].IsZero() {
+				chunkZeroes[chunk] = append(chunkZeroes[chunk], i)
+			}
+		}
+	})
+
+	for i := 0; i < nbChunks; i++ {
+		zeroes = append(zeroes, chunkZeroes[i]...)
+	}
+
+	return
+}
+
+// BatchInvertInPlace replaces every element of a by its inverse.
+// Zero elements are left unchanged.
+//
+// Unlike BatchInvert, it doesn't allocate a result slice; the Montgomery batch
+// inversion trick is applied on blocks of batchInvertBlockSize elements, and
+// needs only one extra element per block.
+func BatchInvertInPlace(a []{{.ElementName}}) {
+	if len(a) == 0 {
+		return
+	}
+
+	batchInvertParallel(len(a), batchInvertNbChunks(len(a)), func(_, start, end int) {
+		batchInvertInPlace(a[start:end])
+	})
+}
+
+const (
+	// batchInvertMinChunkSize is the minimum number of elements processed by
+	// a single goroutine in the batch inversion functions, which costs one field inversion
+	batchInvertMinChunkSize = 1 << 10
+
+	// batchInvertBlockSize is the size of the scratch space used by BatchInvertInPlace
+	batchInvertBlockSize = 1 << 10
+)
+
+// batchInvertNbChunks returns the number of chunks an input of size n is split into
+func batchInvertNbChunks(n int) int {
+	nbChunks := runtime.NumCPU()
+	if maxChunks := n / batchInvertMinChunkSize; maxChunks < nbChunks {
+		nbChunks = maxChunks
+	}
+	if nbChunks < 1 {
+		nbChunks = 1
+	}
+	return nbChunks
+}
+
+// batchInvertParallel splits [0, n) in nbChunks contiguous chunks and calls work on each of them
+// in a separate goroutine
+func batchInvertParallel(n, nbChunks int, work func(chunk, start, end int)) {
+	if nbChunks == 1 {
+		work(0, 0, n)
+		return
+	}
+
+	chunkSize := n / nbChunks
+	extra := n % nbChunks
+
+	var wg sync.WaitGroup
+	wg.Add(nbChunks)
+	start := 0
+	for i := 0; i < nbChunks; i++ {
+		end := start + chunkSize
+		if i < extra {
+			end++
+		}
+		go func(chunk, start, end int) {
+			work(chunk, start, end)
+			wg.Done()
+		}(i, start, end)
+		start = end
+	}
+	wg.Wait()
+}
+
+// batchInvert sets res[i] = a[i]⁻¹ (or 0 if a[i] == 0), using a single field inversion.
+// res and a must have the same length and must not overlap.
+func batchInvert(res, a []{{.ElementName}}) {
 	accumulator := One()
 
-	for i:=0; i < len(a); i++ {
+	for i := 0; i < len(a); i++ {
 		if a[i].IsZero() {
-			zeroes[i] = true
 			continue
 		}
 		res[i] = accumulator
@@ -579,14 +721,66 @@ func BatchInvert(a []{{.ElementName}}) []{{.ElementName}} {
 	accumulator.Inverse(&accumulator)
 
 	for i := len(a) - 1; i >= 0; i-- {
-		if zeroes[i] {
+		if a[i].IsZero() {
 			continue
 		}
 		res[i].Mul(&res[i], &accumulator)
 		accumulator.Mul(&accumulator, &a[i])
 	}
+}
 
-	return res
+// batchInvertInPlace sets a[i] = a[i]⁻¹ (or 0 if a[i] == 0), using a single field inversion.
+func batchInvertInPlace(a []{{.ElementName}}) {
+	nbBlocks := (len(a) + batchInvertBlockSize - 1) / batchInvertBlockSize
+
+	// compute the product of the non-zero elements of each block
+	blockProducts := make([]{{.ElementName}}, nbBlocks)
+	for j := 0; j < nbBlocks; j++ {
+		start, end := j*batchInvertBlockSize, (j+1)*batchInvertBlockSize
+		if end > len(a) {
+			end = len(a)
+		}
+		blockProducts[j].SetOne()
+		for i := start; i < end; i++ {
+			if a[i].IsZero() {
+				continue
+			}
+			blockProducts[j].Mul(&blockProducts[j], &a[i])
+		}
+	}
+
+	// block products are never zero
+	blockInverses := make([]{{.ElementName}}, nbBlocks)
+	batchInvert(blockInverses, blockProducts)
+
+	// apply the batch inversion trick on each block, starting from the inverse of its product
+	prefix := make([]{{.ElementName}}, batchInvertBlockSize)
+	var tmp {{.ElementName}}
+	for j := 0; j < nbBlocks; j++ {
+		start, end := j*batchInvertBlockSize, (j+1)*batchInvertBlockSize
+		if end > len(a) {
+			end = len(a)
+		}
+
+		accumulator := One()
+		for i := start; i < end; i++ {
+			if a[i].IsZero() {
+				continue
+			}
+			prefix[i-start] = accumulator
+			accumulator.Mul(&accumulator, &a[i])
+		}
+
+		accumulator = blockInverses[j]
+		for i := end - 1; i >= start; i-- {
+			if a[i].IsZero() {
+				continue
+			}
+			tmp = a[i]
+			a[i].Mul(&prefix[i-start], &accumulator)
+			accumulator.Mul(&accumulator, &tmp)
+		}
+	}
 }
 
 func _butterflyGeneric(a, b *{{.ElementName}}) {
@@ -600,22 +794,29 @@ func _butterflyGeneric(a, b *{{.ElementName}}) {
 func (z *{{.ElementName}}) BitLen() int {
 	{{- range $i := reverse .NbWordsIndexesNoZero}}
 	if z[{{$i}}] != 0 {
-		return {{mul $i 64}} + bits.Len64(z[{{$i}}])
+		return {{mul $i $.Word.BitSize}} + {{$.Word.Len}}(z[{{$i}}])
 	}
 	{{- end}}
-	return bits.Len64(z[0])
+	return {{.Word.Len}}(z[0])
 }
 
-// Hash msg to count prime field elements.
+// Hash msg to count prime field elements, using expand_message_xmd with SHA-256.
 // https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-06#section-5.2
 func Hash(msg, dst []byte, count int) ([]{{.ElementName}}, error) {
+	return HashWith(hash.ExpandMsgXmd, msg, dst, count)
+}
+
+// HashWith hashes msg to count prime field elements, using expand to produce the uniform bytes
+// (see hash.ExpandMsgXmdWith and hash.ExpandMsgXofWith).
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-5.2
+func HashWith(expand hash.ExpandMsg, msg, dst []byte, count int) ([]{{.ElementName}}, error) {
 	// 128 bits of security
 	// L = ceil((ceil(log2(p)) + k) / 8), where k is the security parameter = 128
 	const Bytes = 1 + (Bits-1)/8
 	const L = 16 + Bytes
 
 	lenInBytes := count * L
-	pseudoRandomBytes, err := hash.ExpandMsgXmd(msg, dst, lenInBytes)
+	pseudoRandomBytes, err := expand(msg, dst, lenInBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,147 @@
+package element
+
+const ConstantTime = `
+
+{{- /* constant time variants of Inverse, Exp and Sqrt, for code handling secrets */}}
+
+// _bInverseExponent{{.ElementName}} is q-2, used in InverseCT
+var _bInverseExponent{{.ElementName}} big.Int
+
+func init() {
+	_bInverseExponent{{.ElementName}}.Sub(&_modulus, big.NewInt(2))
+}
+
+// InverseCT z = x⁻¹ (mod q) in constant time, using Fermat's little theorem z = x^(q-2)
+//
+// The sequence of operations does not depend on the value of x, so InverseCT
+// runs in constant time as long as Mul and Square do. It is much slower than
+// Inverse and should only be used on secret values.
+//
+// if x == 0, sets and returns z = x
+func (z *{{.ElementName}}) InverseCT(x *{{.ElementName}}) *{{.ElementName}} {
+	// the exponent is public, branching on its bits doesn't leak x
+	return z.Exp(*x, &_bInverseExponent{{.ElementName}})
+}
+
+// ExpCT z = xᵏ (mod q) in constant time.
+//
+// The same sequence of operations is performed for any x and any k < 2^{{.NbBits}},
+// using a square and multiply always algorithm with constant time selection.
+// If k is negative, x is inverted with InverseCT; the sign of k is not hidden.
+func (z *{{.ElementName}}) ExpCT(x {{.ElementName}}, k *big.Int) *{{.ElementName}} {
+	e := k
+	if k.Sign() == -1 {
+		// if k < 0: xᵏ (mod q) == (x⁻¹)ᵏ (mod q)
+		x.InverseCT(&x)
+
+		e = pool.BigInt.Get()
+		defer pool.BigInt.Put(e)
+		e.Neg(k)
+	}
+
+	nbBits := e.BitLen()
+	if nbBits < {{.NbBits}} {
+		nbBits = {{.NbBits}}
+	}
+
+	var res, t {{.ElementName}}
+	res.SetOne()
+	for i := nbBits - 1; i >= 0; i-- {
+		res.Square(&res)
+		t.Mul(&res, &x)
+		res.Select(int(e.Bit(i)), &res, &t)
+	}
+
+	return z.Set(&res)
+}
+
+// SqrtCT z = √x (mod q) in constant time.
+//
+// The sequence of operations does not depend on the value of x. Only whether x
+// is a square or not is revealed, by the return value: if the square root
+// doesn't exist (x is not a square mod q), SqrtCT leaves z unchanged and returns nil.
+func (z *{{.ElementName}}) SqrtCT(x *{{.ElementName}}) *{{.ElementName}} {
+	{{- if .SqrtQ3Mod4}}
+		// q ≡ 3 (mod 4)
+		// using  z ≡ ± x^((p+1)/4) (mod q), the exponent is public
+		var y {{.ElementName}}
+		{{- if .UseAddChain}}
+		y.expBySqrtExp(*x)
+		{{- else}}
+		y.Exp(*x, _bSqrtExponent{{.ElementName}})
+		{{- end }}
+	{{- else if .SqrtAtkin}}
+		// q ≡ 5 (mod 8)
+		// see modSqrt5Mod8Prime in math/big/int.go, the exponent is public
+		var one, alpha, y, tx {{.ElementName}}
+		one.SetOne()
+		tx.Double(x)
+		{{- if .UseAddChain}}
+		alpha.expBySqrtExp(tx)
+		{{ else }}
+		alpha.Exp(tx, _bSqrtExponent{{.ElementName}})
+		{{- end }}
+		y.Square(&alpha).
+			Mul(&y, &tx).
+			Sub(&y, &one).
+			Mul(&y, x).
+			Mul(&y, &alpha)
+	{{- else if .SqrtTonelliShanks}}
+		// q ≡ 1 (mod 4)
+		// constant time Tonelli-Shanks, see RFC 9380, appendix I.4
+		// with q - 1 = 2ᵉ⋅s, s odd
+
+		var y, b, t, w, tt, yt, one {{.ElementName}}
+		one.SetOne()
+
+		// w = x^((s-1)/2))
+		{{- if .UseAddChain}}
+		w.expBySqrtExp(*x)
+		{{- else}}
+		w.Exp(*x, _bSqrtExponent{{.ElementName}})
+		{{- end}}
+
+		// y = x^((s+1)/2)) = w * x
+		y.Mul(x, &w)
+
+		// t = xˢ = w * w * x = y * w
+		t.Mul(&w, &y)
+
+		// c = nonResidue ^ s
+		var c = {{.ElementName}}{
+			{{- range $i := .SqrtG}}
+			{{$i}},{{end}}
+		}
+
+		for k := {{.SqrtE}}; k > 1; k-- {
+			b = t
+			for j := 1; j < k-1; j++ {
+				b.Square(&b)
+			}
+			// ne = 0 if b == 1, 1 otherwise
+			d := b.NotEqual(&one)
+			ne := int((d | -d) >> 63)
+
+			yt.Mul(&y, &c)
+			y.Select(ne, &y, &yt)
+			c.Square(&c)
+			tt.Mul(&t, &c)
+			t.Select(ne, &t, &tt)
+		}
+	{{- else}}
+		panic("not implemented")
+	{{- end}}
+
+	{{- if or .SqrtQ3Mod4 .SqrtAtkin .SqrtTonelliShanks}}
+
+	// ensure we found y such that y * y = x
+	var square {{.ElementName}}
+	square.Square(&y)
+	if square.NotEqual(x) != 0 {
+		return nil
+	}
+	return z.Set(&y)
+	{{- end}}
+}
+
+`
@@ -7,9 +7,23 @@ const Doc = `
 // 
 // The modulus is hardcoded in all the operations.
 // 
+{{- if .Mersenne31}}
+// Field elements are represented as an array, in regular (non-Montgomery) form:
+// 	type {{.ElementName}} [{{.NbWords}}]{{.Word.TypeLower}}
+//
+// The modulus is q = 2³¹ - 1: products fit on a uint64 and are reduced with shifts and masks,
+// and elements are encoded on {{.NbBytes}} bytes.
+//
+{{- else}}
 // Field elements are represented as an array, and assumed to be in Montgomery form in all methods:
-// 	type {{.ElementName}} [{{.NbWords}}]uint64
+// 	type {{.ElementName}} [{{.NbWords}}]{{.Word.TypeLower}}
+//
+{{- if .F31}}
+// The modulus fits on 31 bits: the Montgomery constant is R = 2³², products fit on a uint64
+// and are reduced with a single 32x32 → 64 bits multiplication; elements are encoded on {{.NbBytes}} bytes.
 //
+{{- end}}
+{{- end}}
 // Usage
 //
 // Example API signature:
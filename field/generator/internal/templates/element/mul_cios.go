@@ -122,6 +122,30 @@ const MulCIOS = `
 	}
 	z[0] = r 
 {{ end }}
+
+{{ define "mul_f31" }}
+	// q < 2³¹, so x * y < 2⁶² fits on a uint64
+	t := uint64({{$.V1}}[0]) * uint64({{$.V2}}[0])
+	{{- if $.all.Mersenne31}}
+	// q = 2³¹ - 1 and 2³¹ ≡ 1 mod q: the high bits are folded onto the low ones,
+	// t < 2³² after the first fold and t ≤ q + 1 after the second one
+	t = (t & uint64(q)) + (t >> 31)
+	t = (t & uint64(q)) + (t >> 31)
+	if t >= uint64(q) {
+		t -= uint64(q)
+	}
+	z[0] = uint32(t)
+	{{- else}}
+	// Montgomery reduction with R = 2³²: m = t * qInvNeg mod R and R | t + m * q,
+	// r = (t + m * q) / R < (q² + R * q) / R < 2q
+	m := uint32(t) * qInvNeg
+	r := (t + uint64(m)*uint64(q)) >> 32
+	if r >= uint64(q) {
+		r -= uint64(q)
+	}
+	z[0] = uint32(r)
+	{{- end}}
+{{ end }}
 `
 
 const MulDoc = `
@@ -2,7 +2,9 @@ package element
 
 const OpsNoAsm = `
 
+{{- if not .F31}}
 import "math/bits"
+{{- end}}
 
 {{ $mulConsts := list 3 5 13 }}
 {{- range $i := $mulConsts }}
@@ -58,7 +60,9 @@ func reduce(z *{{.ElementName}})  {
 // x and y must be less than q
 {{- end }}
 func (z *{{.ElementName}}) Mul(x, y *{{.ElementName}}) *{{.ElementName}} {
-	{{- if eq $.NbWords 1}}
+	{{- if $.F31}}
+		{{ template "mul_f31" dict "all" . "V1" "x" "V2" "y" }}
+	{{- else if eq $.NbWords 1}}
 		{{ template "mul_cios_one_limb" dict "all" . "V1" "x" "V2" "y" }}
 	{{- else }}
 		{{ mul_doc $.NoCarry }}
@@ -79,7 +83,9 @@ func (z *{{.ElementName}}) Mul(x, y *{{.ElementName}}) *{{.ElementName}} {
 {{- end }}
 func (z *{{.ElementName}}) Square(x *{{.ElementName}}) *{{.ElementName}} {
 	// see Mul for algorithm documentation
-	{{- if eq $.NbWords 1}}
+	{{- if $.F31}}
+		{{ template "mul_f31" dict "all" . "V1" "x" "V2" "x" }}
+	{{- else if eq $.NbWords 1}}
 		{{ template "mul_cios_one_limb" dict "all" . "V1" "x" "V2" "x" }}
 	{{- else }}
 		{{- if $.NoCarry}}
@@ -19,8 +19,13 @@ import (
 	ggen "github.com/leanovate/gopter/gen"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/field"
 )
 
+// {{.ElementName}} must satisfy the generic field.Element constraint
+var _ = field.BatchInvert[{{.ElementName}}, *{{.ElementName}}]
+
 
 // -------------------------------------------------------------------------------------------------
 // benchmarks
@@ -98,6 +103,36 @@ func Benchmark{{toTitle .ElementName}}Inverse(b *testing.B) {
 
 }
 
+func Benchmark{{toTitle .ElementName}}InverseCT(b *testing.B) {
+	var x {{.ElementName}}
+	x.SetRandom()
+	benchRes{{.ElementName}}.SetRandom()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		benchRes{{.ElementName}}.InverseCT(&x)
+	}
+}
+
+func Benchmark{{toTitle .ElementName}}BatchInvert(b *testing.B) {
+	const size = 1 << 16
+	a := make([]{{.ElementName}}, size)
+	for i := 0; i < size; i++ {
+		a[i].SetRandom()
+	}
+
+	b.Run("BatchInvert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = BatchInvert(a)
+		}
+	})
+	b.Run("BatchInvertInPlace", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			BatchInvertInPlace(a)
+		}
+	})
+}
+
 func Benchmark{{toTitle .ElementName}}Butterfly(b *testing.B) {
 	var x {{.ElementName}}
 	x.SetRandom()
@@ -1356,6 +1391,87 @@ func Test{{toTitle .ElementName}}NegativeExp(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func Test{{toTitle .ElementName}}ConstantTime(t *testing.T) {
+	t.Parallel()
+
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	properties.Property("InverseCT == Inverse", prop.ForAll(
+		func(a testPair{{.ElementName}}) bool {
+			var b, c {{.ElementName}}
+			b.Inverse(&a.element)
+			c.InverseCT(&a.element)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("ExpCT == Exp", prop.ForAll(
+		func(a, b testPair{{.ElementName}}) bool {
+			var c, d {{.ElementName}}
+			var nb big.Int
+			nb.Neg(&b.bigint)
+			c.Exp(a.element, &b.bigint)
+			d.ExpCT(a.element, &b.bigint)
+			if !c.Equal(&d) {
+				return false
+			}
+			c.Exp(a.element, &nb)
+			d.ExpCT(a.element, &nb)
+			return c.Equal(&d)
+		},
+		genA, genA,
+	))
+
+	properties.Property("SqrtCT == Sqrt", prop.ForAll(
+		func(a testPair{{.ElementName}}) bool {
+			var b, c, d {{.ElementName}}
+			b.Square(&a.element)
+			for _, x := range []*{{.ElementName}}{&a.element, &b} {
+				sqrtOk := c.Sqrt(x) != nil
+				sqrtCTOk := d.SqrtCT(x) != nil
+				if sqrtOk != sqrtCTOk {
+					return false
+				}
+				if sqrtOk && !c.Equal(&d) {
+					var c2, d2 {{.ElementName}}
+					// both are square roots of x, possibly of opposite signs
+					c2.Square(&c)
+					d2.Square(&d)
+					if !c2.Equal(&d2) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	var zero, one, r {{.ElementName}}
+	one.SetOne()
+	if r.InverseCT(&zero); !r.IsZero() {
+		t.Fatal("InverseCT(0) should be 0")
+	}
+	if r.ExpCT(one, big.NewInt(0)); !r.IsOne() {
+		t.Fatal("ExpCT(x, 0) should be 1")
+	}
+	if r.SqrtCT(&zero) == nil || !r.IsZero() {
+		t.Fatal("SqrtCT(0) should be 0")
+	}
+}
+
 func Test{{toTitle .ElementName}}New{{.ElementName}}(t *testing.T) {
 	assert := require.New(t)
 
@@ -1408,6 +1524,55 @@ func Test{{toTitle .ElementName}}BatchInvert(t *testing.T) {
 
 		assert.True(len(aInv) == len(a))
 
+		aInvInPlace := make([]{{.ElementName}}, len(a))
+		copy(aInvInPlace, a)
+		BatchInvertInPlace(aInvInPlace)
+		assert.Equal(aInv, aInvInPlace, "BatchInvertInPlace != BatchInvert")
+
+		aInvReport, zeroes := BatchInvertReportZeroes(a)
+		assert.Equal(aInv, aInvReport, "BatchInvertReportZeroes != BatchInvert")
+		var expectedZeroes []int
+		for i:=0; i <len(a);i++ {
+			if a[i].IsZero() {
+				expectedZeroes = append(expectedZeroes, i)
+			}
+		}
+		assert.Equal(expectedZeroes, zeroes, "BatchInvertReportZeroes reported wrong indices")
+
+		for i:=0; i <len(a);i++ {
+			if a[i].IsZero() {
+				assert.True(aInv[i].IsZero(), "0⁻¹ != 0")
+			} else {
+				assert.True(a[i].Mul(&a[i], &aInv[i]).IsOne(), "x * x⁻¹ != 1")
+			}
+		}
+	}
+
+	// large inputs are split in chunks, and in blocks for the in place variant
+	{
+		const size = 3*batchInvertBlockSize + 17
+		a := make([]{{.ElementName}}, size)
+		var expectedZeroes []int
+		for i:=0; i <len(a);i++ {
+			if i % 101 == 0 {
+				expectedZeroes = append(expectedZeroes, i)
+				continue
+			}
+			// small moduli may sample 0
+			for a[i].IsZero() {
+				a[i].SetRandom()
+			}
+		}
+
+		aInv, zeroes := BatchInvertReportZeroes(a)
+		assert.Equal(expectedZeroes, zeroes, "BatchInvertReportZeroes reported wrong indices")
+		assert.Equal(aInv, BatchInvert(a), "BatchInvertReportZeroes != BatchInvert")
+
+		aInvInPlace := make([]{{.ElementName}}, len(a))
+		copy(aInvInPlace, a)
+		BatchInvertInPlace(aInvInPlace)
+		assert.Equal(aInv, aInvInPlace, "BatchInvertInPlace != BatchInvert")
+
 		for i:=0; i <len(a);i++ {
 			if a[i].IsZero() {
 				assert.True(aInv[i].IsZero(), "0⁻¹ != 0")
@@ -1567,15 +1732,17 @@ type testPair{{.ElementName}} struct {
 }
 
 
+{{- $nextWord := "genParams.NextUint64()"}}
+{{- if .F31}}{{$nextWord = "uint32(genParams.NextUint64())"}}{{end}}
 func gen() gopter.Gen {
 	return func(genParams *gopter.GenParameters) *gopter.GenResult {
 		var g testPair{{.ElementName}}
 
 		g.element = {{.ElementName}}{
 			{{- range $i := .NbWordsIndexesFull}}
-			genParams.NextUint64(),{{end}}
+			{{$nextWord}},{{end}}
 		}
-		if q{{.ElementName}}[{{.NbWordsLastIndex}}] != ^uint64(0) {
+		if q{{.ElementName}}[{{.NbWordsLastIndex}}] != ^{{.Word.TypeLower}}(0) {
 			g.element[{{.NbWordsLastIndex}}] %= (q{{.ElementName}}[{{.NbWordsLastIndex}}] +1 )
 		}
 		
@@ -1583,9 +1750,9 @@ func gen() gopter.Gen {
 		for !g.element.smallerThanModulus() {
 			g.element = {{.ElementName}}{
 				{{- range $i := .NbWordsIndexesFull}}
-				genParams.NextUint64(),{{end}}
+				{{$nextWord}},{{end}}
 			}
-			if q{{.ElementName}}[{{.NbWordsLastIndex}}] != ^uint64(0) {
+			if q{{.ElementName}}[{{.NbWordsLastIndex}}] != ^{{.Word.TypeLower}}(0) {
 				g.element[{{.NbWordsLastIndex}}] %= (q{{.ElementName}}[{{.NbWordsLastIndex}}] +1 )
 			}
 		}
@@ -1605,19 +1772,19 @@ func genFull() gopter.Gen {
 
 			g = {{.ElementName}}{
 				{{- range $i := .NbWordsIndexesFull}}
-				genParams.NextUint64(),{{end}}
+				{{$nextWord}},{{end}}
 			}
 
-			if q{{.ElementName}}[{{.NbWordsLastIndex}}] != ^uint64(0) {
+			if q{{.ElementName}}[{{.NbWordsLastIndex}}] != ^{{.Word.TypeLower}}(0) {
 				g[{{.NbWordsLastIndex}}] %= (q{{.ElementName}}[{{.NbWordsLastIndex}}] +1 )
 			}
 
 			for !g.smallerThanModulus() {
 				g = {{.ElementName}}{
 					{{- range $i := .NbWordsIndexesFull}}
-					genParams.NextUint64(),{{end}}
+					{{$nextWord}},{{end}}
 				}
-				if q{{.ElementName}}[{{.NbWordsLastIndex}}] != ^uint64(0) {
+				if q{{.ElementName}}[{{.NbWordsLastIndex}}] != ^{{.Word.TypeLower}}(0) {
 					g[{{.NbWordsLastIndex}}] %= (q{{.ElementName}}[{{.NbWordsLastIndex}}] +1 )
 				}
 			}
@@ -1626,12 +1793,12 @@ func genFull() gopter.Gen {
 		}
 		a := genRandomFq()
 
-		var carry uint64
+		var carry {{.Word.TypeLower}}
 		{{- range $i := .NbWordsIndexesFull}}
 			{{- if eq $i $.NbWordsLastIndex}}
-			a[{{$i}}], _ = bits.Add64(a[{{$i}}], q{{$.ElementName}}[{{$i}}], carry)
+			a[{{$i}}], _ = {{$.Word.Add}}(a[{{$i}}], q{{$.ElementName}}[{{$i}}], carry)
 			{{- else}}
-			a[{{$i}}], carry = bits.Add64(a[{{$i}}], q{{$.ElementName}}[{{$i}}], carry)
+			a[{{$i}}], carry = {{$.Word.Add}}(a[{{$i}}], q{{$.ElementName}}[{{$i}}], carry)
 			{{- end}}
 		{{- end}}
 		
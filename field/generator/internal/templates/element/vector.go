@@ -118,4 +118,130 @@ func (vector Vector) Swap(i, j int) {
 	vector[i], vector[j] = vector[j], vector[i]
 }
 
+// ScalarMul multiplies a vector by a scalar element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector Vector) ScalarMul(a Vector, b *{{.ElementName}}) {
+	if len(a) != len(vector) {
+		panic("vector.ScalarMul: vectors don't have the same length")
+	}
+	for i := 0; i < len(a); i++ {
+		vector[i].Mul(&a[i], b)
+	}
+}
+
+// Mul multiplies two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector Vector) Mul(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(vector) {
+		panic("vector.Mul: vectors don't have the same length")
+	}
+	for i := 0; i < len(a); i++ {
+		vector[i].Mul(&a[i], &b[i])
+	}
+}
+
+// Sum computes the sum of all elements in the vector.
+func (vector Vector) Sum() (res {{.ElementName}}) {
+	for i := 0; i < len(vector); i++ {
+		res.Add(&res, &vector[i])
+	}
+	return
+}
+
+// Random returns a vector of n elements sampled uniformly in [0, q), reading randomness from r
+// (see {{.ElementName}}.SetRandomFrom).
+func Random(r io.Reader, n int) (Vector, error) {
+	vector := make(Vector, n)
+	for i := range vector {
+		if _, err := vector[i].SetRandomFrom(r); err != nil {
+			return nil, err
+		}
+	}
+	return vector, nil
+}
+
+// InnerProduct computes the inner product of two vectors.
+// It panics if the vectors don't have the same length.
+func (vector Vector) InnerProduct(other Vector) (res {{.ElementName}}) {
+	if len(vector) != len(other) {
+		panic("vector.InnerProduct: vectors don't have the same length")
+	}
+	var tmp {{.ElementName}}
+	for i := 0; i < len(vector); i++ {
+		tmp.Mul(&vector[i], &other[i])
+		res.Add(&res, &tmp)
+	}
+	return
+}
+
+func addVecGeneric(res, a, b Vector) {
+	if len(a) != len(b) || len(a) != len(res) {
+		panic("vector.Add: vectors don't have the same length")
+	}
+	for i := 0; i < len(a); i++ {
+		res[i].Add(&a[i], &b[i])
+	}
+}
+
+func subVecGeneric(res, a, b Vector) {
+	if len(a) != len(b) || len(a) != len(res) {
+		panic("vector.Sub: vectors don't have the same length")
+	}
+	for i := 0; i < len(a); i++ {
+		res[i].Sub(&a[i], &b[i])
+	}
+}
+
+`
+
+// VectorOpsAMD64 is included with AMD64 builds when the field has assembly vector operations
+const VectorOpsAMD64 = `
+
+// Add adds two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector Vector) Add(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(vector) {
+		panic("vector.Add: vectors don't have the same length")
+	}
+	if len(a) == 0 {
+		return
+	}
+	addVec(&vector[0], &a[0], &b[0], uint64(len(a)))
+}
+
+//go:noescape
+func addVec(res, a, b *{{.ElementName}}, n uint64)
+
+// Sub subtracts two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector Vector) Sub(a, b Vector) {
+	if len(a) != len(b) || len(a) != len(vector) {
+		panic("vector.Sub: vectors don't have the same length")
+	}
+	if len(a) == 0 {
+		return
+	}
+	subVec(&vector[0], &a[0], &b[0], uint64(len(a)))
+}
+
+//go:noescape
+func subVec(res, a, b *{{.ElementName}}, n uint64)
+
+`
+
+// VectorOpsPureGo is included with non-AMD64 builds, or when the field has no assembly vector operations
+const VectorOpsPureGo = `
+
+// Add adds two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector Vector) Add(a, b Vector) {
+	addVecGeneric(vector, a, b)
+}
+
+// Sub subtracts two vectors element-wise and stores the result in self.
+// It panics if the vectors don't have the same length.
+func (vector Vector) Sub(a, b Vector) {
+	subVecGeneric(vector, a, b)
+}
+
 `
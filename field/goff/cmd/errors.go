@@ -17,5 +17,6 @@ package cmd
 import "errors"
 
 var (
-	errMissingArgument = errors.New("missing argument")
+	errMissingArgument   = errors.New("missing argument")
+	errMissingImportPath = errors.New("--import is required to generate the fft or polynomial packages")
 )
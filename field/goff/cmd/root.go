@@ -22,8 +22,12 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/consensys/bavard"
 	"github.com/consensys/gnark-crypto/field/generator"
 	field "github.com/consensys/gnark-crypto/field/generator/config"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+	"github.com/consensys/gnark-crypto/internal/generator/fft"
+	"github.com/consensys/gnark-crypto/internal/generator/polynomial"
 	"github.com/spf13/cobra"
 )
 
@@ -40,6 +44,10 @@ var (
 	fOutputDir   string
 	fPackageName string
 	fElementName string
+	fImportPath  string
+	fFFT         bool
+	fPolynomial  bool
+	fMinLogFFT   uint64
 )
 
 func init() {
@@ -48,6 +56,10 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&fModulus, "modulus", "m", "", "field modulus (base 10)")
 	rootCmd.PersistentFlags().StringVarP(&fOutputDir, "output", "o", "", "destination path to create output files")
 	rootCmd.PersistentFlags().StringVarP(&fPackageName, "package", "p", "", "package name in generated files")
+	rootCmd.PersistentFlags().StringVarP(&fImportPath, "import", "i", "", "import path of the generated package (required by --fft and --polynomial)")
+	rootCmd.PersistentFlags().BoolVar(&fFFT, "fft", false, "also generate the fft package (domains of power of 2 cardinality) in <output>/fft")
+	rootCmd.PersistentFlags().BoolVar(&fPolynomial, "polynomial", false, "also generate the polynomial package in <output>/polynomial")
+	rootCmd.PersistentFlags().Uint64Var(&fMinLogFFT, "fft-min-log-size", 16, "with --fft, minimum log₂ of the largest fft domain the field must support")
 	if bits.UintSize != 64 {
 		panic("goff only supports 64bits architectures")
 	}
@@ -71,10 +83,49 @@ func cmdGenerate(cmd *cobra.Command, args []string) {
 		fmt.Printf("\n%s\n", err.Error())
 		os.Exit(-1)
 	}
+	// check the fft parameters before generating anything
+	var fftConfig config.FFT
+	if fFFT {
+		if fftConfig, err = config.NewFFT(F.ModulusBig, fMinLogFFT); err != nil {
+			fmt.Printf("\ncan't generate fft package: %s\n", err.Error())
+			os.Exit(-1)
+		}
+	}
 	if err := generator.GenerateFF(F, fOutputDir); err != nil {
 		fmt.Printf("\n%s\n", err.Error())
 		os.Exit(-1)
 	}
+	if err := generateDependents(F, fftConfig); err != nil {
+		fmt.Printf("\n%s\n", err.Error())
+		os.Exit(-1)
+	}
+}
+
+// generateDependents generates the packages built on top of the field element, as requested by the flags
+func generateDependents(F *field.FieldConfig, fftConfig config.FFT) error {
+	if !fFFT && !fPolynomial {
+		return nil
+	}
+	bgen := bavard.NewBatchGenerator("ConsenSys Software Inc.", 2020, "consensys/gnark-crypto")
+	fieldDependency := config.FieldDependency{
+		FieldPackagePath: fImportPath,
+		FieldPackageName: F.PackageName,
+		ElementType:      F.PackageName + "." + F.ElementName,
+	}
+
+	if fFFT {
+		conf := fft.Config{FieldDependency: fieldDependency, FFT: fftConfig}
+		if err := fft.Generate(conf, filepath.Join(fOutputDir, "fft"), bgen); err != nil {
+			return err
+		}
+	}
+
+	if fPolynomial {
+		if err := polynomial.Generate(polynomial.Config{FieldDependency: fieldDependency, GenerateTests: true, NoFFT: !fFFT}, filepath.Join(fOutputDir, "polynomial"), bgen); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func parseFlags(cmd *cobra.Command) error {
@@ -84,6 +135,9 @@ func parseFlags(cmd *cobra.Command) error {
 		fElementName == "" {
 		return errMissingArgument
 	}
+	if (fFFT || fPolynomial) && fImportPath == "" {
+		return errMissingImportPath
+	}
 
 	// clean inputs
 	fOutputDir = filepath.Clean(fOutputDir)
@@ -20,6 +20,11 @@
 //
 //	goff -m 0xffffffff00000001 -o ./goldilocks/ -p goldilocks -e Element
 //
+// The fft and polynomial packages can be generated alongside the element (in ./goldilocks/fft and ./goldilocks/polynomial);
+// they need the import path of the generated package:
+//
+//	goff -m 0xffffffff00000001 -o ./goldilocks/ -p goldilocks -e Element -i example.com/goldilocks --fft --polynomial
+//
 // # Warning
 //
 // The generated code has not been audited for all moduli (only bn254 and bls12-381) and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
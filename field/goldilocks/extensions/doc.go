@@ -0,0 +1,22 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package extensions provides the quadratic and cubic extensions of goldilocks.
+//
+// E2 = goldilocks[u]/(u²-7) and E3 = goldilocks[v]/(v³-7).
+// They are typically used to sample challenges in a large enough field when
+// the base field is too small for the required soundness.
+package extensions
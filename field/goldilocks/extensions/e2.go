@@ -0,0 +1,359 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package extensions
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/field/goldilocks"
+)
+
+// SizeOfE2 is the number of bytes needed to represent an E2 element
+const SizeOfE2 = 2 * goldilocks.Bytes
+
+// E2 is a degree two finite field extension of goldilocks.Element:
+// A0 + A1⋅u with u² = β
+type E2 struct {
+	A0, A1 goldilocks.Element
+}
+
+// Equal returns true if z equals x, false otherwise
+func (z *E2) Equal(x *E2) bool {
+	return z.A0.Equal(&x.A0) && z.A1.Equal(&x.A1)
+}
+
+// SetString sets a E2 element from strings
+func (z *E2) SetString(s1, s2 string) (*E2, error) {
+	if _, err := z.A0.SetString(s1); err != nil {
+		return nil, err
+	}
+	if _, err := z.A1.SetString(s2); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// SetZero sets an E2 elmt to zero
+func (z *E2) SetZero() *E2 {
+	z.A0.SetZero()
+	z.A1.SetZero()
+	return z
+}
+
+// Set sets an E2 from x
+func (z *E2) Set(x *E2) *E2 {
+	z.A0 = x.A0
+	z.A1 = x.A1
+	return z
+}
+
+// SetOne sets z to 1 and returns z
+func (z *E2) SetOne() *E2 {
+	z.A0.SetOne()
+	z.A1.SetZero()
+	return z
+}
+
+// SetRandom sets a0 and a1 to random values
+func (z *E2) SetRandom() (*E2, error) {
+	if _, err := z.A0.SetRandom(); err != nil {
+		return nil, err
+	}
+	if _, err := z.A1.SetRandom(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// IsZero returns true if z is zero, false otherwise
+func (z *E2) IsZero() bool {
+	return z.A0.IsZero() && z.A1.IsZero()
+}
+
+// IsOne returns true if z is one, false otherwise
+func (z *E2) IsOne() bool {
+	return z.A0.IsOne() && z.A1.IsZero()
+}
+
+// Add adds two elements of E2
+func (z *E2) Add(x, y *E2) *E2 {
+	z.A0.Add(&x.A0, &y.A0)
+	z.A1.Add(&x.A1, &y.A1)
+	return z
+}
+
+// Sub two elements of E2
+func (z *E2) Sub(x, y *E2) *E2 {
+	z.A0.Sub(&x.A0, &y.A0)
+	z.A1.Sub(&x.A1, &y.A1)
+	return z
+}
+
+// Double doubles an E2 element
+func (z *E2) Double(x *E2) *E2 {
+	z.A0.Double(&x.A0)
+	z.A1.Double(&x.A1)
+	return z
+}
+
+// Neg negates an E2 element
+func (z *E2) Neg(x *E2) *E2 {
+	z.A0.Neg(&x.A0)
+	z.A1.Neg(&x.A1)
+	return z
+}
+
+// Mul sets z to the E2-product of x,y, returns z
+func (z *E2) Mul(x, y *E2) *E2 {
+	var a, b, c goldilocks.Element
+	a.Add(&x.A0, &x.A1)
+	b.Add(&y.A0, &y.A1)
+	a.Mul(&a, &b)
+	b.Mul(&x.A0, &y.A0)
+	c.Mul(&x.A1, &y.A1)
+	z.A1.Sub(&a, &b).Sub(&z.A1, &c)
+	mulByNonResidue(&c, &c)
+	z.A0.Add(&b, &c)
+	return z
+}
+
+// Square sets z to the E2-product of x,x returns z
+func (z *E2) Square(x *E2) *E2 {
+	var a, b goldilocks.Element
+	a.Square(&x.A0)
+	b.Square(&x.A1)
+	mulByNonResidue(&b, &b)
+	z.A1.Mul(&x.A0, &x.A1).Double(&z.A1)
+	z.A0.Add(&a, &b)
+	return z
+}
+
+// MulByElement multiplies an element in E2 by an element in goldilocks
+func (z *E2) MulByElement(x *E2, y *goldilocks.Element) *E2 {
+	var yCopy goldilocks.Element
+	yCopy.Set(y)
+	z.A0.Mul(&x.A0, &yCopy)
+	z.A1.Mul(&x.A1, &yCopy)
+	return z
+}
+
+// Conjugate conjugates an element in E2
+func (z *E2) Conjugate(x *E2) *E2 {
+	z.A0 = x.A0
+	z.A1.Neg(&x.A1)
+	return z
+}
+
+// Frobenius sets z to xᵖ and returns z.
+//
+// Since β is a non-square, uᵖ = β^((p-1)/2)⋅u = -u and the Frobenius map is the conjugation.
+func (z *E2) Frobenius(x *E2) *E2 {
+	return z.Conjugate(x)
+}
+
+// Norm returns the norm of z, i.e. z⋅zᵖ = A0² - β⋅A1²
+func (z *E2) Norm() goldilocks.Element {
+	var a, b goldilocks.Element
+	a.Square(&z.A0)
+	b.Square(&z.A1)
+	mulByNonResidue(&b, &b)
+	return *a.Sub(&a, &b)
+}
+
+// Inverse sets z to the E2-inverse of x, returns z
+//
+// if x == 0, sets and returns z = x
+func (z *E2) Inverse(x *E2) *E2 {
+	n := x.Norm()
+	n.Inverse(&n)
+	z.A0.Mul(&x.A0, &n)
+	z.A1.Mul(&x.A1, &n).Neg(&z.A1)
+	return z
+}
+
+// Div sets z = x / y and returns z
+func (z *E2) Div(x *E2, y *E2) *E2 {
+	var r E2
+	r.Inverse(y).Mul(x, &r)
+	return z.Set(&r)
+}
+
+// Exp sets z=xᵏ (mod p²) and returns it
+func (z *E2) Exp(x E2, k *big.Int) *E2 {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod p²) == (x⁻¹)ᵏ (mod p²)
+		x.Inverse(&x)
+		e = new(big.Int).Neg(k)
+	}
+
+	z.SetOne()
+	b := e.Bytes()
+	for i := 0; i < len(b); i++ {
+		w := b[i]
+		for j := 0; j < 8; j++ {
+			z.Square(z)
+			if (w & (0b10000000 >> j)) != 0 {
+				z.Mul(z, &x)
+			}
+		}
+	}
+
+	return z
+}
+
+// Legendre returns the Legendre symbol of z
+func (z *E2) Legendre() int {
+	n := z.Norm()
+	return n.Legendre()
+}
+
+// Sqrt sets z to a square root of x and returns z.
+// If x is not a square in E2, Sqrt leaves z unchanged and returns nil.
+func (z *E2) Sqrt(x *E2) *E2 {
+	var a goldilocks.Element
+	if x.A1.IsZero() {
+		// x is in goldilocks: either x is a square in goldilocks or x/β is
+		if a.Sqrt(&x.A0) != nil {
+			z.A0 = a
+			z.A1.SetZero()
+			return z
+		}
+		a.Mul(&x.A0, &nonResidueInv)
+		if a.Sqrt(&a) == nil {
+			return nil
+		}
+		z.A0.SetZero()
+		z.A1 = a
+		return z
+	}
+
+	// x = a + b⋅u is a square iff its norm is a square in goldilocks.
+	// Then √x = x0 + x1⋅u with x0² = (a ± √N(x))/2 and x1 = b/(2⋅x0),
+	// where exactly one of the two signs yields a square.
+	n := x.Norm()
+	if n.Sqrt(&n) == nil {
+		return nil
+	}
+	var delta, x0, x1 goldilocks.Element
+	delta.Add(&x.A0, &n)
+	delta.Halve()
+	if x0.Sqrt(&delta) == nil {
+		delta.Sub(&x.A0, &n)
+		delta.Halve()
+		if x0.Sqrt(&delta) == nil {
+			return nil
+		}
+	}
+	x1.Double(&x0).Inverse(&x1).Mul(&x1, &x.A1)
+	z.A0 = x0
+	z.A1 = x1
+	return z
+}
+
+// Select is conditional move.
+// If cond = 0, it sets z to caseZ and returns it. otherwise caseNz.
+func (z *E2) Select(cond int, caseZ *E2, caseNz *E2) *E2 {
+	z.A0.Select(cond, &caseZ.A0, &caseNz.A0)
+	z.A1.Select(cond, &caseZ.A1, &caseNz.A1)
+	return z
+}
+
+// String implements Stringer interface for fancy printing
+func (z *E2) String() string {
+	return z.A0.String() + "+" + z.A1.String() + "*u"
+}
+
+// Bytes returns the value of z as a big-endian byte array, A0 first
+func (z *E2) Bytes() (res [SizeOfE2]byte) {
+	b := z.A0.Bytes()
+	copy(res[:goldilocks.Bytes], b[:])
+	b = z.A1.Bytes()
+	copy(res[goldilocks.Bytes:], b[:])
+	return
+}
+
+// SetBytes interprets e as the big-endian encoding of an E2 (as returned by Bytes)
+// and sets z to that value. It returns an error if len(e) != SizeOfE2 or if one of
+// the coordinates is not canonical (i.e. not smaller than the modulus).
+func (z *E2) SetBytes(e []byte) error {
+	if len(e) != SizeOfE2 {
+		return errors.New("invalid E2 encoding size")
+	}
+	var r E2
+	if err := r.A0.SetBytesCanonical(e[:goldilocks.Bytes]); err != nil {
+		return err
+	}
+	if err := r.A1.SetBytesCanonical(e[goldilocks.Bytes:]); err != nil {
+		return err
+	}
+	z.Set(&r)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (z *E2) MarshalBinary() ([]byte, error) {
+	b := z.Bytes()
+	return b[:], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (z *E2) UnmarshalBinary(data []byte) error {
+	return z.SetBytes(data)
+}
+
+// BatchInvertE2 returns a new slice with every element inverted.
+// Uses Montgomery batch inversion trick
+//
+// if a[i] == 0, returns result[i] = a[i]
+func BatchInvertE2(a []E2) []E2 {
+	res := make([]E2, len(a))
+	if len(a) == 0 {
+		return res
+	}
+
+	zeroes := make([]bool, len(a))
+	var accumulator E2
+	accumulator.SetOne()
+
+	for i := 0; i < len(a); i++ {
+		if a[i].IsZero() {
+			zeroes[i] = true
+			continue
+		}
+		res[i].Set(&accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	accumulator.Inverse(&accumulator)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		if zeroes[i] {
+			continue
+		}
+		res[i].Mul(&res[i], &accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	return res
+}
@@ -0,0 +1,438 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package extensions
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/field/goldilocks"
+)
+
+// SizeOfE3 is the number of bytes needed to represent an E3 element
+const SizeOfE3 = 3 * goldilocks.Bytes
+
+// E3 is a degree three finite field extension of goldilocks.Element:
+// A0 + A1⋅v + A2⋅v² with v³ = β
+type E3 struct {
+	A0, A1, A2 goldilocks.Element
+}
+
+// Equal returns true if z equals x, false otherwise
+func (z *E3) Equal(x *E3) bool {
+	return z.A0.Equal(&x.A0) && z.A1.Equal(&x.A1) && z.A2.Equal(&x.A2)
+}
+
+// SetString sets a E3 element from strings
+func (z *E3) SetString(s1, s2, s3 string) (*E3, error) {
+	if _, err := z.A0.SetString(s1); err != nil {
+		return nil, err
+	}
+	if _, err := z.A1.SetString(s2); err != nil {
+		return nil, err
+	}
+	if _, err := z.A2.SetString(s3); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// SetZero sets an E3 elmt to zero
+func (z *E3) SetZero() *E3 {
+	z.A0.SetZero()
+	z.A1.SetZero()
+	z.A2.SetZero()
+	return z
+}
+
+// Set sets an E3 from x
+func (z *E3) Set(x *E3) *E3 {
+	z.A0 = x.A0
+	z.A1 = x.A1
+	z.A2 = x.A2
+	return z
+}
+
+// SetOne sets z to 1 and returns z
+func (z *E3) SetOne() *E3 {
+	z.A0.SetOne()
+	z.A1.SetZero()
+	z.A2.SetZero()
+	return z
+}
+
+// SetRandom sets a0, a1 and a2 to random values
+func (z *E3) SetRandom() (*E3, error) {
+	if _, err := z.A0.SetRandom(); err != nil {
+		return nil, err
+	}
+	if _, err := z.A1.SetRandom(); err != nil {
+		return nil, err
+	}
+	if _, err := z.A2.SetRandom(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// IsZero returns true if z is zero, false otherwise
+func (z *E3) IsZero() bool {
+	return z.A0.IsZero() && z.A1.IsZero() && z.A2.IsZero()
+}
+
+// IsOne returns true if z is one, false otherwise
+func (z *E3) IsOne() bool {
+	return z.A0.IsOne() && z.A1.IsZero() && z.A2.IsZero()
+}
+
+// Add adds two elements of E3
+func (z *E3) Add(x, y *E3) *E3 {
+	z.A0.Add(&x.A0, &y.A0)
+	z.A1.Add(&x.A1, &y.A1)
+	z.A2.Add(&x.A2, &y.A2)
+	return z
+}
+
+// Sub two elements of E3
+func (z *E3) Sub(x, y *E3) *E3 {
+	z.A0.Sub(&x.A0, &y.A0)
+	z.A1.Sub(&x.A1, &y.A1)
+	z.A2.Sub(&x.A2, &y.A2)
+	return z
+}
+
+// Double doubles an E3 element
+func (z *E3) Double(x *E3) *E3 {
+	z.A0.Double(&x.A0)
+	z.A1.Double(&x.A1)
+	z.A2.Double(&x.A2)
+	return z
+}
+
+// Neg negates an E3 element
+func (z *E3) Neg(x *E3) *E3 {
+	z.A0.Neg(&x.A0)
+	z.A1.Neg(&x.A1)
+	z.A2.Neg(&x.A2)
+	return z
+}
+
+// Mul sets z to the E3-product of x,y, returns z
+func (z *E3) Mul(x, y *E3) *E3 {
+	// Algorithm 13 from https://eprint.iacr.org/2010/354.pdf
+	var t0, t1, t2, c0, c1, c2, tmp goldilocks.Element
+	t0.Mul(&x.A0, &y.A0)
+	t1.Mul(&x.A1, &y.A1)
+	t2.Mul(&x.A2, &y.A2)
+
+	c0.Add(&x.A1, &x.A2)
+	tmp.Add(&y.A1, &y.A2)
+	c0.Mul(&c0, &tmp).Sub(&c0, &t1).Sub(&c0, &t2)
+	mulByNonResidue(&c0, &c0)
+	c0.Add(&c0, &t0)
+
+	c1.Add(&x.A0, &x.A1)
+	tmp.Add(&y.A0, &y.A1)
+	c1.Mul(&c1, &tmp).Sub(&c1, &t0).Sub(&c1, &t1)
+	mulByNonResidue(&tmp, &t2)
+	c1.Add(&c1, &tmp)
+
+	c2.Add(&x.A0, &x.A2)
+	tmp.Add(&y.A0, &y.A2)
+	c2.Mul(&c2, &tmp).Sub(&c2, &t0).Sub(&c2, &t2).Add(&c2, &t1)
+
+	z.A0 = c0
+	z.A1 = c1
+	z.A2 = c2
+	return z
+}
+
+// Square sets z to the E3-product of x,x, returns z
+func (z *E3) Square(x *E3) *E3 {
+	// Algorithm 16 from https://eprint.iacr.org/2010/354.pdf
+	var c4, c5, c1, c2, c3, c0 goldilocks.Element
+	c4.Mul(&x.A0, &x.A1).Double(&c4)
+	c5.Square(&x.A2)
+	mulByNonResidue(&c1, &c5)
+	c1.Add(&c1, &c4)
+	c2.Sub(&c4, &c5)
+	c3.Square(&x.A0)
+	c4.Sub(&x.A0, &x.A1).Add(&c4, &x.A2)
+	c5.Mul(&x.A1, &x.A2).Double(&c5)
+	c4.Square(&c4)
+	mulByNonResidue(&c0, &c5)
+	c0.Add(&c0, &c3)
+	z.A2.Add(&c2, &c4).Add(&z.A2, &c5).Sub(&z.A2, &c3)
+	z.A0 = c0
+	z.A1 = c1
+	return z
+}
+
+// MulByElement multiplies an element in E3 by an element in goldilocks
+func (z *E3) MulByElement(x *E3, y *goldilocks.Element) *E3 {
+	var yCopy goldilocks.Element
+	yCopy.Set(y)
+	z.A0.Mul(&x.A0, &yCopy)
+	z.A1.Mul(&x.A1, &yCopy)
+	z.A2.Mul(&x.A2, &yCopy)
+	return z
+}
+
+// Frobenius sets z to xᵖ and returns z
+func (z *E3) Frobenius(x *E3) *E3 {
+	z.A0 = x.A0
+	z.A1.Mul(&x.A1, &frobeniusCoeff1)
+	z.A2.Mul(&x.A2, &frobeniusCoeff2)
+	return z
+}
+
+// FrobeniusSquare sets z to xᵖ² and returns z
+func (z *E3) FrobeniusSquare(x *E3) *E3 {
+	// ω³ = 1 so that vᵖ² = ω²⋅v and v²ᵖ² = ω⁴⋅v² = ω⋅v²
+	z.A0 = x.A0
+	z.A1.Mul(&x.A1, &frobeniusCoeff2)
+	z.A2.Mul(&x.A2, &frobeniusCoeff1)
+	return z
+}
+
+// adjugate sets z to the E3 element such that x⋅z = N(x) and returns N(x)
+func (z *E3) adjugate(x *E3) goldilocks.Element {
+	var c0, c1, c2, t, tmp goldilocks.Element
+
+	// c0 = a0² - β⋅a1⋅a2
+	c0.Square(&x.A0)
+	tmp.Mul(&x.A1, &x.A2)
+	mulByNonResidue(&tmp, &tmp)
+	c0.Sub(&c0, &tmp)
+
+	// c1 = β⋅a2² - a0⋅a1
+	c1.Square(&x.A2)
+	mulByNonResidue(&c1, &c1)
+	tmp.Mul(&x.A0, &x.A1)
+	c1.Sub(&c1, &tmp)
+
+	// c2 = a1² - a0⋅a2
+	c2.Square(&x.A1)
+	tmp.Mul(&x.A0, &x.A2)
+	c2.Sub(&c2, &tmp)
+
+	// N(x) = a0⋅c0 + β⋅(a2⋅c1 + a1⋅c2)
+	t.Mul(&x.A2, &c1)
+	tmp.Mul(&x.A1, &c2)
+	t.Add(&t, &tmp)
+	mulByNonResidue(&t, &t)
+	tmp.Mul(&x.A0, &c0)
+	t.Add(&t, &tmp)
+
+	z.A0 = c0
+	z.A1 = c1
+	z.A2 = c2
+	return t
+}
+
+// Norm returns the norm of z, i.e. z⋅zᵖ⋅zᵖ²
+func (z *E3) Norm() goldilocks.Element {
+	var adj E3
+	return adj.adjugate(z)
+}
+
+// Inverse sets z to the E3-inverse of x, returns z
+//
+// if x == 0, sets and returns z = x
+func (z *E3) Inverse(x *E3) *E3 {
+	var adj E3
+	n := adj.adjugate(x)
+	n.Inverse(&n)
+	return z.MulByElement(&adj, &n)
+}
+
+// Div sets z = x / y and returns z
+func (z *E3) Div(x *E3, y *E3) *E3 {
+	var r E3
+	r.Inverse(y).Mul(x, &r)
+	return z.Set(&r)
+}
+
+// Exp sets z=xᵏ (mod p³) and returns it
+func (z *E3) Exp(x E3, k *big.Int) *E3 {
+	if k.IsUint64() && k.Uint64() == 0 {
+		return z.SetOne()
+	}
+
+	e := k
+	if k.Sign() == -1 {
+		// negative k, we invert
+		// if k < 0: xᵏ (mod p³) == (x⁻¹)ᵏ (mod p³)
+		x.Inverse(&x)
+		e = new(big.Int).Neg(k)
+	}
+
+	z.SetOne()
+	b := e.Bytes()
+	for i := 0; i < len(b); i++ {
+		w := b[i]
+		for j := 0; j < 8; j++ {
+			z.Square(z)
+			if (w & (0b10000000 >> j)) != 0 {
+				z.Mul(z, &x)
+			}
+		}
+	}
+
+	return z
+}
+
+// Legendre returns the Legendre symbol of z
+func (z *E3) Legendre() int {
+	// the extension has odd degree, so z is a square iff its norm is
+	n := z.Norm()
+	return n.Legendre()
+}
+
+// Sqrt sets z to a square root of x and returns z.
+// If x is not a square in E3, Sqrt leaves z unchanged and returns nil.
+func (z *E3) Sqrt(x *E3) *E3 {
+	// Tonelli-Shanks, with p³-1 = 2ˢ⋅t
+	if x.IsZero() {
+		return z.SetZero()
+	}
+
+	var y, b, t, g E3
+	y.Exp(*x, &sqrtE3TPlusOneDiv2)
+	b.Exp(*x, &sqrtE3T)
+	g.Set(&sqrtE3NonResidueT)
+	r := sqrtE3S
+
+	for !b.IsOne() {
+		// find the least m such that b^(2ᵐ) = 1
+		m := 0
+		t.Set(&b)
+		for !t.IsOne() {
+			t.Square(&t)
+			m++
+			if m == r {
+				// x is not a square
+				return nil
+			}
+		}
+
+		// t = g^(2^(r-m-1))
+		t.Set(&g)
+		for i := 0; i < r-m-1; i++ {
+			t.Square(&t)
+		}
+		g.Square(&t)
+		y.Mul(&y, &t)
+		b.Mul(&b, &g)
+		r = m
+	}
+
+	return z.Set(&y)
+}
+
+// Select is conditional move.
+// If cond = 0, it sets z to caseZ and returns it. otherwise caseNz.
+func (z *E3) Select(cond int, caseZ *E3, caseNz *E3) *E3 {
+	z.A0.Select(cond, &caseZ.A0, &caseNz.A0)
+	z.A1.Select(cond, &caseZ.A1, &caseNz.A1)
+	z.A2.Select(cond, &caseZ.A2, &caseNz.A2)
+	return z
+}
+
+// String implements Stringer interface for fancy printing
+func (z *E3) String() string {
+	return z.A0.String() + "+(" + z.A1.String() + ")*v+(" + z.A2.String() + ")*v**2"
+}
+
+// Bytes returns the value of z as a big-endian byte array, A0 first
+func (z *E3) Bytes() (res [SizeOfE3]byte) {
+	b := z.A0.Bytes()
+	copy(res[:goldilocks.Bytes], b[:])
+	b = z.A1.Bytes()
+	copy(res[goldilocks.Bytes:2*goldilocks.Bytes], b[:])
+	b = z.A2.Bytes()
+	copy(res[2*goldilocks.Bytes:], b[:])
+	return
+}
+
+// SetBytes interprets e as the big-endian encoding of an E3 (as returned by Bytes)
+// and sets z to that value. It returns an error if len(e) != SizeOfE3 or if one of
+// the coordinates is not canonical (i.e. not smaller than the modulus).
+func (z *E3) SetBytes(e []byte) error {
+	if len(e) != SizeOfE3 {
+		return errors.New("invalid E3 encoding size")
+	}
+	var r E3
+	if err := r.A0.SetBytesCanonical(e[:goldilocks.Bytes]); err != nil {
+		return err
+	}
+	if err := r.A1.SetBytesCanonical(e[goldilocks.Bytes : 2*goldilocks.Bytes]); err != nil {
+		return err
+	}
+	if err := r.A2.SetBytesCanonical(e[2*goldilocks.Bytes:]); err != nil {
+		return err
+	}
+	z.Set(&r)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (z *E3) MarshalBinary() ([]byte, error) {
+	b := z.Bytes()
+	return b[:], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (z *E3) UnmarshalBinary(data []byte) error {
+	return z.SetBytes(data)
+}
+
+// BatchInvertE3 returns a new slice with every element inverted.
+// Uses Montgomery batch inversion trick
+//
+// if a[i] == 0, returns result[i] = a[i]
+func BatchInvertE3(a []E3) []E3 {
+	res := make([]E3, len(a))
+	if len(a) == 0 {
+		return res
+	}
+
+	zeroes := make([]bool, len(a))
+	var accumulator E3
+	accumulator.SetOne()
+
+	for i := 0; i < len(a); i++ {
+		if a[i].IsZero() {
+			zeroes[i] = true
+			continue
+		}
+		res[i].Set(&accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	accumulator.Inverse(&accumulator)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		if zeroes[i] {
+			continue
+		}
+		res[i].Mul(&res[i], &accumulator)
+		accumulator.Mul(&accumulator, &a[i])
+	}
+
+	return res
+}
@@ -0,0 +1,438 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package extensions
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/field/goldilocks"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+// nonSquareE3 is β, which is not a square in goldilocks nor in the odd degree extension E3
+var nonSquareE3 = E3{A0: goldilocks.NewElement(NonResidue)}
+
+func TestE3ReceiverIsOperand(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE3()
+	genB := GenE3()
+	genBase := GenBase()
+
+	properties.Property("[GOLDILOCKS] Having the receiver as operand (addition) should output the same result", prop.ForAll(
+		func(a, b *E3) bool {
+			var c, d E3
+			d.Set(a)
+			c.Add(a, b)
+			a.Add(a, b)
+			b.Add(&d, b)
+			return a.Equal(b) && a.Equal(&c) && b.Equal(&c)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[GOLDILOCKS] Having the receiver as operand (sub) should output the same result", prop.ForAll(
+		func(a, b *E3) bool {
+			var c, d E3
+			d.Set(a)
+			c.Sub(a, b)
+			a.Sub(a, b)
+			b.Sub(&d, b)
+			return a.Equal(b) && a.Equal(&c) && b.Equal(&c)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[GOLDILOCKS] Having the receiver as operand (mul) should output the same result", prop.ForAll(
+		func(a, b *E3) bool {
+			var c, d E3
+			d.Set(a)
+			c.Mul(a, b)
+			a.Mul(a, b)
+			b.Mul(&d, b)
+			return a.Equal(b) && a.Equal(&c) && b.Equal(&c)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[GOLDILOCKS] Having the receiver as operand (square) should output the same result", prop.ForAll(
+		func(a *E3) bool {
+			var b E3
+			b.Square(a)
+			a.Square(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] Having the receiver as operand (Inverse) should output the same result", prop.ForAll(
+		func(a *E3) bool {
+			var b E3
+			b.Inverse(a)
+			a.Inverse(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] Having the receiver as operand (Frobenius) should output the same result", prop.ForAll(
+		func(a *E3) bool {
+			var b E3
+			b.Frobenius(a)
+			a.Frobenius(a)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] Having the receiver as operand (mul by element) should output the same result", prop.ForAll(
+		func(a *E3, b goldilocks.Element) bool {
+			var c E3
+			c.MulByElement(a, &b)
+			a.MulByElement(a, &b)
+			return a.Equal(&c)
+		},
+		genA,
+		genBase,
+	))
+
+	properties.Property("[GOLDILOCKS] Having the receiver as operand (Sqrt) should output the same result", prop.ForAll(
+		func(a *E3) bool {
+			var b, c E3
+			c.Square(a)
+			b.Sqrt(&c)
+			c.Sqrt(&c)
+			return c.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestE3Ops(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := GenE3()
+	genB := GenE3()
+	genBase := GenBase()
+
+	properties.Property("[GOLDILOCKS] sub & add should leave an element invariant", prop.ForAll(
+		func(a, b *E3) bool {
+			var c E3
+			c.Set(a)
+			c.Add(&c, b).Sub(&c, b)
+			return c.Equal(a)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[GOLDILOCKS] mul & inverse should leave an element invariant", prop.ForAll(
+		func(a, b *E3) bool {
+			var c, d E3
+			d.Inverse(b)
+			c.Set(a)
+			c.Mul(&c, b).Mul(&c, &d)
+			return c.Equal(a)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[GOLDILOCKS] mul should be commutative", prop.ForAll(
+		func(a, b *E3) bool {
+			var c, d E3
+			c.Mul(a, b)
+			d.Mul(b, a)
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[GOLDILOCKS] inverse twice should leave an element invariant", prop.ForAll(
+		func(a *E3) bool {
+			var b E3
+			b.Inverse(a).Inverse(&b)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] neg twice should leave an element invariant", prop.ForAll(
+		func(a *E3) bool {
+			var b E3
+			b.Neg(a).Neg(&b)
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] square and mul should output the same result", prop.ForAll(
+		func(a *E3) bool {
+			var b, c E3
+			b.Mul(a, a)
+			c.Square(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] Double and add twice should output the same result", prop.ForAll(
+		func(a *E3) bool {
+			var b, c E3
+			b.Add(a, a)
+			c.Double(a)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] MulByElement and Mul by an element of the base field should output the same result", prop.ForAll(
+		func(a *E3, b goldilocks.Element) bool {
+			var c, d E3
+			c.MulByElement(a, &b)
+			d.A0 = b
+			d.Mul(a, &d)
+			return c.Equal(&d)
+		},
+		genA,
+		genBase,
+	))
+
+	properties.Property("[GOLDILOCKS] Exp by p^3-1 should be 1", prop.ForAll(
+		func(a *E3) bool {
+			var b E3
+			q := goldilocks.Modulus()
+			k := new(big.Int).Exp(q, big.NewInt(3), nil)
+			k.Sub(k, big.NewInt(1))
+			b.Exp(*a, k)
+			return b.IsOne()
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] Exp by a negative exponent should invert", prop.ForAll(
+		func(a *E3) bool {
+			var b, c E3
+			b.Exp(*a, big.NewInt(-5))
+			c.Exp(*a, big.NewInt(5)).Inverse(&c)
+			return b.Equal(&c)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] Frobenius of x in E3 should be equal to xᵖ", prop.ForAll(
+		func(a *E3) bool {
+			var b, c E3
+			b.Frobenius(a)
+			c.Exp(*a, goldilocks.Modulus())
+			return c.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] Frobenius square of x in E3 should be equal to xᵖ²", prop.ForAll(
+		func(a *E3) bool {
+			var b, c E3
+			q := goldilocks.Modulus()
+			q.Mul(q, q)
+			b.FrobeniusSquare(a)
+			c.Exp(*a, q)
+			return c.Equal(&b)
+		},
+		genA,
+	))
+	properties.Property("[GOLDILOCKS] norm should be multiplicative and in the base field", prop.ForAll(
+		func(a, b *E3) bool {
+			var c E3
+			c.Mul(a, b)
+			na, nb, nc := a.Norm(), b.Norm(), c.Norm()
+			na.Mul(&na, &nb)
+			return na.Equal(&nc)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("[GOLDILOCKS] sqrt(x²) should be ±x", prop.ForAll(
+		func(a *E3) bool {
+			var b, c, d E3
+			b.Square(a)
+			if c.Sqrt(&b) == nil {
+				return false
+			}
+			d.Neg(&c)
+			return c.Equal(a) || d.Equal(a)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] Legendre on square should output 1", prop.ForAll(
+		func(a *E3) bool {
+			var b E3
+			b.Square(a)
+			return b.Legendre() == 1
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] non-squares should have Legendre -1 and no square root", prop.ForAll(
+		func(a *E3) bool {
+			var b, c E3
+			b.Square(a)
+			// multiplying a square by a non-square gives a non-square
+			b.Mul(&b, &nonSquareE3)
+			c.SetOne()
+			return b.Legendre() == -1 && c.Sqrt(&b) == nil && c.IsOne()
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] bytes round trip should leave an element invariant", prop.ForAll(
+		func(a *E3) bool {
+			var b E3
+			buf, err := a.MarshalBinary()
+			if err != nil {
+				return false
+			}
+			if err := b.UnmarshalBinary(buf); err != nil {
+				return false
+			}
+			return a.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("[GOLDILOCKS] dividing then multiplying by the same element does nothing", prop.ForAll(
+		func(a, b *E3) bool {
+			var c E3
+			c.Div(a, b)
+			c.Mul(&c, b)
+			return c.Equal(a)
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestE3BatchInvert(t *testing.T) {
+	const n = 17
+	a := make([]E3, n)
+	for i := range a {
+		if i%5 == 0 {
+			continue // leave some zeroes
+		}
+		a[i].SetRandom()
+	}
+
+	res := BatchInvertE3(a)
+	for i := range a {
+		var expected E3
+		expected.Inverse(&a[i])
+		if !res[i].Equal(&expected) {
+			t.Fatalf("batch inverse mismatch at index %d", i)
+		}
+	}
+}
+
+func TestE3SetBytes(t *testing.T) {
+	var a E3
+	if err := a.SetBytes(make([]byte, SizeOfE3-1)); err == nil {
+		t.Fatal("expected an error on a short buffer")
+	}
+
+	// a non canonical coordinate must be rejected
+	buf := make([]byte, SizeOfE3)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < goldilocks.Bytes; i++ {
+		buf[i] = 0xff
+	}
+	if err := a.SetBytes(buf); err == nil {
+		t.Fatal("expected an error on a non canonical encoding")
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkE3Mul(b *testing.B) {
+	var a, c E3
+	_, _ = a.SetRandom()
+	_, _ = c.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Mul(&a, &c)
+	}
+}
+
+func BenchmarkE3Square(b *testing.B) {
+	var a E3
+	_, _ = a.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Square(&a)
+	}
+}
+
+func BenchmarkE3Inverse(b *testing.B) {
+	var a, c E3
+	_, _ = c.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.Inverse(&c)
+	}
+}
+
+func BenchmarkE3Sqrt(b *testing.B) {
+	var a, c E3
+	_, _ = a.SetRandom()
+	a.Square(&a)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Sqrt(&a)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package extensions
+
+import (
+	"github.com/consensys/gnark-crypto/field/goldilocks"
+	"github.com/leanovate/gopter"
+)
+
+const (
+	nbFuzzShort = 10
+	nbFuzz      = 50
+)
+
+// GenBase generates a goldilocks.Element elmt
+func GenBase() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var elmt goldilocks.Element
+
+		if _, err := elmt.SetRandom(); err != nil {
+			panic(err)
+		}
+		genResult := gopter.NewGenResult(elmt, gopter.NoShrinker)
+		return genResult
+	}
+}
+
+// GenE2 generates an E2 elmt
+func GenE2() gopter.Gen {
+	return gopter.CombineGens(
+		GenBase(),
+		GenBase(),
+	).Map(func(values []interface{}) *E2 {
+		return &E2{A0: values[0].(goldilocks.Element), A1: values[1].(goldilocks.Element)}
+	})
+}
+
+// GenE3 generates an E3 elmt
+func GenE3() gopter.Gen {
+	return gopter.CombineGens(
+		GenBase(),
+		GenBase(),
+		GenBase(),
+	).Map(func(values []interface{}) *E3 {
+		return &E3{A0: values[0].(goldilocks.Element), A1: values[1].(goldilocks.Element), A2: values[2].(goldilocks.Element)}
+	})
+}
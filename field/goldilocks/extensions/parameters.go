@@ -0,0 +1,68 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package extensions
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/field/goldilocks"
+)
+
+// NonResidue is the quadratic and cubic non-residue β defining
+// E2 = goldilocks[u]/(u²-β) and E3 = goldilocks[v]/(v³-β)
+const NonResidue = 7
+
+var (
+	// nonResidue is β as a field element, nonResidueInv is β⁻¹
+	nonResidue, nonResidueInv goldilocks.Element
+
+	// frobeniusCoeff1 = ω = β^((p-1)/3), frobeniusCoeff2 = ω²
+	// they satisfy vᵖ = ω⋅v and v²ᵖ = ω²⋅v²
+	frobeniusCoeff1, frobeniusCoeff2 goldilocks.Element
+
+	// p³-1 = 2ˢ⋅t with t odd, used for square roots in E3
+	sqrtE3S                     int
+	sqrtE3T, sqrtE3TPlusOneDiv2 big.Int
+	sqrtE3NonResidueT           E3 // β^t
+)
+
+func init() {
+	nonResidue.SetUint64(NonResidue)
+	nonResidueInv.Inverse(&nonResidue)
+
+	p := goldilocks.Modulus()
+	var e big.Int
+	e.Sub(p, big.NewInt(1)).Div(&e, big.NewInt(3))
+	frobeniusCoeff1.Exp(nonResidue, &e)
+	frobeniusCoeff2.Square(&frobeniusCoeff1)
+
+	// p³-1 = 2ˢ⋅t
+	sqrtE3T.Mul(p, p).Mul(&sqrtE3T, p).Sub(&sqrtE3T, big.NewInt(1))
+	sqrtE3S = int(sqrtE3T.TrailingZeroBits())
+	sqrtE3T.Rsh(&sqrtE3T, uint(sqrtE3S))
+	sqrtE3TPlusOneDiv2.Add(&sqrtE3T, big.NewInt(1)).Rsh(&sqrtE3TPlusOneDiv2, 1)
+
+	// β is a non-square in goldilocks, and remains so in the odd degree extension E3
+	var b E3
+	b.A0.Set(&nonResidue)
+	sqrtE3NonResidueT.Exp(b, &sqrtE3T)
+}
+
+// mulByNonResidue sets z = β⋅x and returns z
+func mulByNonResidue(z, x *goldilocks.Element) *goldilocks.Element {
+	return z.Mul(x, &nonResidue)
+}
@@ -1,16 +1,75 @@
 package hash
 
 import (
+	"crypto"
 	"crypto/sha256"
 	"errors"
+	"fmt"
+	stdhash "hash"
+
+	"golang.org/x/crypto/sha3"
 )
 
-// ExpandMsgXmd expands msg to a slice of lenInBytes bytes.
+// ExpandMsg expands msg to a slice of lenInBytes uniformly random bytes, using the domain separation tag dst.
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-5.3
+type ExpandMsg func(msg, dst []byte, lenInBytes int) ([]byte, error)
+
+// ExpandMsgXmd expands msg to a slice of lenInBytes bytes, using expand_message_xmd with SHA-256.
 // https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-06#section-5
 // https://tools.ietf.org/html/rfc8017#section-4.1 (I2OSP/O2ISP)
 func ExpandMsgXmd(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	return expandMsgXmd(sha256.New(), msg, dst, lenInBytes)
+}
+
+// ExpandMsgXmdWith returns expand_message_xmd instantiated with the hash function h
+// (for instance crypto.SHA512 or crypto.SHA3_256). h must be linked into the binary
+// (e.g. import _ "crypto/sha512"); SHA-3 functions are registered by this package.
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-5.3.1
+func ExpandMsgXmdWith(h crypto.Hash) ExpandMsg {
+	return func(msg, dst []byte, lenInBytes int) ([]byte, error) {
+		if !h.Available() {
+			return nil, fmt.Errorf("hash function %s is not available", h)
+		}
+		return expandMsgXmd(h.New(), msg, dst, lenInBytes)
+	}
+}
+
+// ExpandMsgXofWith returns expand_message_xof instantiated with the extendable-output function
+// built by newXOF (for instance sha3.NewShake128 or sha3.NewShake256).
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-5.3.2
+func ExpandMsgXofWith(newXOF func() sha3.ShakeHash) ExpandMsg {
+	return func(msg, dst []byte, lenInBytes int) ([]byte, error) {
+		if lenInBytes > 0xffff {
+			return nil, errors.New("invalid lenInBytes")
+		}
+		if len(dst) > 255 {
+			return nil, errors.New("invalid domain size (>255 bytes)")
+		}
+
+		// msg_prime = msg ∥ I2OSP(len_in_bytes, 2) ∥ DST_prime
+		// uniform_bytes = H(msg_prime, len_in_bytes)
+		h := newXOF()
+		if _, err := h.Write(msg); err != nil {
+			return nil, err
+		}
+		if _, err := h.Write([]byte{uint8(lenInBytes >> 8), uint8(lenInBytes)}); err != nil {
+			return nil, err
+		}
+		if _, err := h.Write(dst); err != nil {
+			return nil, err
+		}
+		if _, err := h.Write([]byte{uint8(len(dst))}); err != nil {
+			return nil, err
+		}
+		res := make([]byte, lenInBytes)
+		if _, err := h.Read(res); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
 
-	h := sha256.New()
+func expandMsgXmd(h stdhash.Hash, msg, dst []byte, lenInBytes int) ([]byte, error) {
 	ell := (lenInBytes + h.Size() - 1) / h.Size() // ceil(len_in_bytes / b_in_bytes)
 	if ell > 255 {
 		return nil, errors.New("invalid lenInBytes")
@@ -59,7 +118,7 @@ func ExpandMsgXmd(msg, dst []byte, lenInBytes int) ([]byte, error) {
 	b1 := h.Sum(nil)
 
 	res := make([]byte, lenInBytes)
-	copy(res[:h.Size()], b1)
+	copy(res[:min(h.Size(), len(res))], b1)
 
 	for i := 2; i <= ell; i++ {
 		// b_i = H(strxor(b₀, b_(i - 1)) ∥ I2OSP(i, 1) ∥ DST_prime)
@@ -2,8 +2,12 @@ package hash
 
 import (
 	"bytes"
+	"crypto"
+	_ "crypto/sha512"
 	"encoding/hex"
 	"testing"
+
+	"golang.org/x/crypto/sha3"
 )
 
 type expandMsgXmdTestCase struct {
@@ -130,3 +134,66 @@ func TestExpandMsgXmd(t *testing.T) {
 		}
 	}
 }
+
+// Test vectors from https://www.rfc-editor.org/rfc/rfc9380.html Sections K.3 (SHA-512),
+// K.4 (SHAKE128) and K.6 (SHAKE256).
+// The SHA3-256 vectors are not in the standard: they were computed with Python's hashlib.sha3_256
+// and a direct transcription of Section 5.3.1, which also reproduces the K.3 vectors with SHA-512.
+func TestExpandMsgWith(t *testing.T) {
+	t.Run("expand_message_xmd/SHA-512", func(t *testing.T) {
+		testExpandMsg(t, ExpandMsgXmdWith(crypto.SHA512), "QUUX-V01-CS02-with-expander-SHA512-256", []expandMsgXmdTestCase{
+			{"", 0x20, "6b9a7312411d92f921c6f68ca0b6380730a1a4d982c507211a90964c394179ba"},
+			{"abc", 0x20, "0da749f12fbe5483eb066a5f595055679b976e93abe9be6f0f6318bce7aca8dc"},
+			{"abcdef0123456789", 0x20, "087e45a86e2939ee8b91100af1583c4938e0f5fc6c9db4b107b83346bc967f58"},
+			{"", 0x80, "41b037d1734a5f8df225dd8c7de38f851efdb45c372887be655212d07251b921b052b62eaed99b46f72f2ef4cc96bfaf254ebbbec091e1a3b9e4fb5e5b619d2e0c5414800a1d882b62bb5cd1778f098b8eb6cb399d5d9d18f5d5842cf5d13d7eb00a7cff859b605da678b318bd0e65ebff70bec88c753b159a805d2c89c55961"},
+			{"abc", 0x80, "7f1dddd13c08b543f2e2037b14cefb255b44c83cc397c1786d975653e36a6b11bdd7732d8b38adb4a0edc26a0cef4bb45217135456e58fbca1703cd6032cb1347ee720b87972d63fbf232587043ed2901bce7f22610c0419751c065922b488431851041310ad659e4b23520e1772ab29dcdeb2002222a363f0c2b1c972b3efe1"},
+			{"abcdef0123456789", 0x80, "3f721f208e6199fe903545abc26c837ce59ac6fa45733f1baaf0222f8b7acb0424814fcb5eecf6c1d38f06e9d0a6ccfbf85ae612ab8735dfdf9ce84c372a77c8f9e1c1e952c3a61b7567dd0693016af51d2745822663d0c2367e3f4f0bed827feecc2aaf98c949b5ed0d35c3f1023d64ad1407924288d366ea159f46287e61ac"},
+		})
+	})
+	t.Run("expand_message_xof/SHAKE128", func(t *testing.T) {
+		testExpandMsg(t, ExpandMsgXofWith(sha3.NewShake128), "QUUX-V01-CS02-with-expander-SHAKE128", []expandMsgXmdTestCase{
+			{"", 0x20, "86518c9cd86581486e9485aa74ab35ba150d1c75c88e26b7043e44e2acd735a2"},
+			{"abc", 0x20, "8696af52a4d862417c0763556073f47bc9b9ba43c99b505305cb1ec04a9ab468"},
+			{"abcdef0123456789", 0x20, "912c58deac4821c3509dbefa094df54b34b8f5d01a191d1d3108a2c89077acca"},
+			{"", 0x80, "7314ff1a155a2fb99a0171dc71b89ab6e3b2b7d59e38e64419b8b6294d03ffee42491f11370261f436220ef787f8f76f5b26bdcd850071920ce023f3ac46847744f4612b8714db8f5db83205b2e625d95afd7d7b4d3094d3bdde815f52850bb41ead9822e08f22cf41d615a303b0d9dde73263c049a7b9898208003a739a2e57"},
+			{"abc", 0x80, "c952f0c8e529ca8824acc6a4cab0e782fc3648c563ddb00da7399f2ae35654f4860ec671db2356ba7baa55a34a9d7f79197b60ddae6e64768a37d699a78323496db3878c8d64d909d0f8a7de4927dcab0d3dbbc26cb20a49eceb0530b431cdf47bc8c0fa3e0d88f53b318b6739fbed7d7634974f1b5c386d6230c76260d5337a"},
+			{"abcdef0123456789", 0x80, "19b65ee7afec6ac06a144f2d6134f08eeec185f1a890fe34e68f0e377b7d0312883c048d9b8a1d6ecc3b541cb4987c26f45e0c82691ea299b5e6889bbfe589153016d8131717ba26f07c3c14ffbef1f3eff9752e5b6183f43871a78219a75e7000fbac6a7072e2b83c790a3a5aecd9d14be79f9fd4fb180960a3772e08680495"},
+		})
+	})
+	t.Run("expand_message_xof/SHAKE256", func(t *testing.T) {
+		testExpandMsg(t, ExpandMsgXofWith(sha3.NewShake256), "QUUX-V01-CS02-with-expander-SHAKE256", []expandMsgXmdTestCase{
+			{"", 0x20, "2ffc05c48ed32b95d72e807f6eab9f7530dd1c2f013914c8fed38c5ccc15ad76"},
+			{"abc", 0x20, "b39e493867e2767216792abce1f2676c197c0692aed061560ead251821808e07"},
+			{"abcdef0123456789", 0x20, "245389cf44a13f0e70af8665fe5337ec2dcd138890bb7901c4ad9cfceb054b65"},
+			{"", 0x80, "7a1361d2d7d82d79e035b8880c5a3c86c5afa719478c007d96e6c88737a3f631dd74a2c88df79a4cb5e5d9f7504957c70d669ec6bfedc31e01e2bacc4ff3fdf9b6a00b17cc18d9d72ace7d6b81c2e481b4f73f34f9a7505dccbe8f5485f3d20c5409b0310093d5d6492dea4e18aa6979c23c8ea5de01582e9689612afbb353df"},
+			{"abc", 0x80, "a54303e6b172909783353ab05ef08dd435a558c3197db0c132134649708e0b9b4e34fb99b92a9e9e28fc1f1d8860d85897a8e021e6382f3eea10577f968ff6df6c45fe624ce65ca25932f679a42a404bc3681efe03fcd45ef73bb3a8f79ba784f80f55ea8a3c367408f30381299617f50c8cf8fbb21d0f1e1d70b0131a7b6fbe"},
+			{"abcdef0123456789", 0x80, "e42e4d9538a189316e3154b821c1bafb390f78b2f010ea404e6ac063deb8c0852fcd412e098e231e43427bd2be1330bb47b4039ad57b30ae1fc94e34993b162ff4d695e42d59d9777ea18d3848d9d336c25d2acb93adcad009bcfb9cde12286df267ada283063de0bb1505565b2eb6c90e31c48798ecdc71a71756a9110ff373"},
+		})
+	})
+	t.Run("expand_message_xmd/SHA3-256", func(t *testing.T) {
+		testExpandMsg(t, ExpandMsgXmdWith(crypto.SHA3_256), "QUUX-V01-CS02-with-expander-SHA3-256", []expandMsgXmdTestCase{
+			{"", 0x20, "0633e7abc9098228c749e7cc1c08f7c28067a005df8b21ce2f877e157543593c"},
+			{"abc", 0x20, "dd81222340c0b06f53921eee61ced0ee3b542bb2dbde6ba6ffa62fb9ab372163"},
+			{"abcdef0123456789", 0x20, "41b36d82c93e510aea44d5675c46a54dc4e70d17f430c43b4f88f82489acb123"},
+			{"", 0x80, "78ff86e603a20850aa2f93a76c34fab410f776cb9d633f8bc70c4b96caca13c463034abdb854f061118269df1406f37e12a17327fea43f7003611dffc7b331a2665d058d6ddadeace6980822cef5e78ed1aeb003ecafe0aaf0f13c28f9c91791a9e83f4568df6fee0ac2b3187972d3cac9186df6f1acf15ba5df87c0986654f8"},
+			{"abc", 0x80, "d3235ad97df2cf0402dc75c1373351f230a23fad135bb552fc22e572e1ebbc9e26f4692d8cb2bf1b8ba16a22371ea490ac8e83a7d580f80b3c65598b910c47e4a6bc4a904cbe21dd3ed60883e7635f4f3045fce99df48fd0195c8457405fe49697de589bca6cdd9af91063643d9c64caab9e63ec0658267cda21be35ace6f20f"},
+			{"abcdef0123456789", 0x80, "fd39c020f57c24ddc3b8109f1f3b9c7f299b33324dc6ed51486f66b9decd053850cba0ba349185fa79c4352b310a48fd964bebcef9c5d98c479784e44b9d2f29ebf3119db97ed69c06fc70f0959c4abaa290aa30b5541406d7837bea41c5c2b61383fc32796688d7cf4e6fb5d6fa30e87ceffbc881bbc3e9a935fa278229dc3d"},
+		})
+	})
+}
+
+func testExpandMsg(t *testing.T, expand ExpandMsg, dst string, testCases []expandMsgXmdTestCase) {
+	for _, testCase := range testCases {
+		uniformBytes, err := expand([]byte(testCase.msg), []byte(dst), testCase.lenInBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := hex.DecodeString(testCase.uniformBytesHex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(uniformBytes, expected) {
+			t.Errorf("msg %q, lenInBytes %d: expected \"%s\" got \"%x\"", testCase.msg, testCase.lenInBytes, testCase.uniformBytesHex, uniformBytes)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package m31
+
+// MulBy3 x *= 3 (mod q)
+func MulBy3(x *Element) {
+	var y Element
+	y.SetUint64(3)
+	x.Mul(x, &y)
+}
+
+// MulBy5 x *= 5 (mod q)
+func MulBy5(x *Element) {
+	var y Element
+	y.SetUint64(5)
+	x.Mul(x, &y)
+}
+
+// MulBy13 x *= 13 (mod q)
+func MulBy13(x *Element) {
+	var y Element
+	y.SetUint64(13)
+	x.Mul(x, &y)
+}
+
+// Butterfly sets
+//
+//	a = a + b (mod q)
+//	b = a - b (mod q)
+func Butterfly(a, b *Element) {
+	_butterflyGeneric(a, b)
+}
+
+func fromMont(z *Element) {
+	_fromMontGeneric(z)
+}
+
+func reduce(z *Element) {
+	_reduceGeneric(z)
+}
+
+// Mul z = x * y (mod q)
+//
+// x and y must be less than q
+func (z *Element) Mul(x, y *Element) *Element {
+
+	// q < 2³¹, so x * y < 2⁶² fits on a uint64
+	t := uint64(x[0]) * uint64(y[0])
+	// q = 2³¹ - 1 and 2³¹ ≡ 1 mod q: the high bits are folded onto the low ones,
+	// t < 2³² after the first fold and t ≤ q + 1 after the second one
+	t = (t & uint64(q)) + (t >> 31)
+	t = (t & uint64(q)) + (t >> 31)
+	if t >= uint64(q) {
+		t -= uint64(q)
+	}
+	z[0] = uint32(t)
+
+	return z
+}
+
+// Square z = x * x (mod q)
+//
+// x must be less than q
+func (z *Element) Square(x *Element) *Element {
+	// see Mul for algorithm documentation
+
+	// q < 2³¹, so x * y < 2⁶² fits on a uint64
+	t := uint64(x[0]) * uint64(x[0])
+	// q = 2³¹ - 1 and 2³¹ ≡ 1 mod q: the high bits are folded onto the low ones,
+	// t < 2³² after the first fold and t ≤ q + 1 after the second one
+	t = (t & uint64(q)) + (t >> 31)
+	t = (t & uint64(q)) + (t >> 31)
+	if t >= uint64(q) {
+		t -= uint64(q)
+	}
+	z[0] = uint32(t)
+
+	return z
+}
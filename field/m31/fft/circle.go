@@ -0,0 +1,107 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"github.com/consensys/gnark-crypto/field/m31"
+)
+
+// CirclePoint is a point (X, Y) on the circle curve X² + Y² = 1 over m31.
+//
+// The points of the circle form a cyclic group of order p + 1 = 2³¹ with the law
+// (x₀, y₀)⋅(x₁, y₁) = (x₀x₁ - y₀y₁, x₀y₁ + y₀x₁) and identity (1, 0).
+type CirclePoint struct {
+	X, Y m31.Element
+}
+
+// LogOrderCircleGroup is log₂ of the order of the circle group over m31
+const LogOrderCircleGroup = 31
+
+// CircleGenerator returns a generator of the circle group over m31
+func CircleGenerator() CirclePoint {
+	var g CirclePoint
+	g.X.SetUint64(2)
+	g.Y.SetUint64(1268011823)
+	return g
+}
+
+// SetIdentity sets p to the identity (1, 0) and returns p
+func (p *CirclePoint) SetIdentity() *CirclePoint {
+	p.X.SetOne()
+	p.Y.SetZero()
+	return p
+}
+
+// Equal returns true if p equals q, false otherwise
+func (p *CirclePoint) Equal(q *CirclePoint) bool {
+	return p.X.Equal(&q.X) && p.Y.Equal(&q.Y)
+}
+
+// IsOnCircle returns true if X² + Y² = 1
+func (p *CirclePoint) IsOnCircle() bool {
+	var x2, y2 m31.Element
+	x2.Square(&p.X)
+	y2.Square(&p.Y)
+	x2.Add(&x2, &y2)
+	return x2.IsOne()
+}
+
+// Add sets p to the group law of a and b and returns p
+func (p *CirclePoint) Add(a, b *CirclePoint) *CirclePoint {
+	var x, y, t m31.Element
+	x.Mul(&a.X, &b.X)
+	t.Mul(&a.Y, &b.Y)
+	x.Sub(&x, &t)
+	y.Mul(&a.X, &b.Y)
+	t.Mul(&a.Y, &b.X)
+	y.Add(&y, &t)
+	p.X = x
+	p.Y = y
+	return p
+}
+
+// Double sets p = a⋅a, i.e. (2x² - 1, 2xy), and returns p
+func (p *CirclePoint) Double(a *CirclePoint) *CirclePoint {
+	var x, y m31.Element
+	x.Square(&a.X).Double(&x)
+	y.SetOne()
+	x.Sub(&x, &y)
+	y.Mul(&a.X, &a.Y).Double(&y)
+	p.X = x
+	p.Y = y
+	return p
+}
+
+// Conjugate sets p = (x, -y), the inverse of a, and returns p
+func (p *CirclePoint) Conjugate(a *CirclePoint) *CirclePoint {
+	p.X = a.X
+	p.Y.Neg(&a.Y)
+	return p
+}
+
+// ScalarMul sets p = aᵏ in the circle group and returns p
+func (p *CirclePoint) ScalarMul(a *CirclePoint, k uint64) *CirclePoint {
+	var res, base CirclePoint
+	res.SetIdentity()
+	base = *a
+	for ; k != 0; k >>= 1 {
+		if k&1 == 1 {
+			res.Add(&res, &base)
+		}
+		base.Double(&base)
+	}
+	*p = res
+	return p
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fft provides the circle group FFT over the Mersenne-31 field.
+//
+// The multiplicative group of m31 has a 2-adicity of 1, so it does not
+// support the usual radix-2 FFT. Instead, the circle curve x² + y² = 1 has
+// p + 1 = 2³¹ points over m31 and its subgroups of order 2ⁿ are used as
+// evaluation domains (see Circle STARKs, https://eprint.iacr.org/2024/278).
+//
+// A polynomial over a domain of size N = 2ⁿ is represented by its coordinates
+// c₀, ..., c_{N-1} in the basis
+//
+//	bₖ(x, y) = yᵏ⁰ ⋅ xᵏ¹ ⋅ π(x)ᵏ² ⋯ πⁿ⁻²(x)ᵏⁿ⁻¹
+//
+// where kᵢ is the i-th bit of k and π(x) = 2x² - 1 is the x-coordinate of the
+// doubling map.
+package fft
@@ -0,0 +1,118 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/field/m31"
+)
+
+// Domain is a twin coset of the circle group of size N = 2ⁿ:
+//
+//	D = {q⋅gⁱ, 0 ≤ i < N}
+//
+// where q has order 2N and g = q² generates the subgroup of order N.
+// D is stable under conjugation (x, y) ↦ (x, -y) and under the antipode
+// (x, y) ↦ (-x, -y), which is what the circle FFT relies on.
+type Domain struct {
+	Cardinality    uint64
+	CardinalityInv m31.Element
+
+	// Offset is q, the first point of the domain; Generator is g = q²
+	Offset, Generator CirclePoint
+
+	// Twiddles[0][i] is the y-coordinate of the i-th point of the domain, for i < N/2.
+	// Twiddles[l][i] for l ≥ 1 is πˡ⁻¹(x) where x is the x-coordinate of the i-th point
+	// of the domain, for i < N/2ˡ⁺¹.
+	Twiddles [][]m31.Element
+
+	// TwiddlesInv contains the inverses of Twiddles
+	TwiddlesInv [][]m31.Element
+}
+
+// MaxLogCardinality is log₂ of the largest supported domain
+const MaxLogCardinality = LogOrderCircleGroup - 1
+
+// NewDomain returns a circle domain of size m, rounded up to the next power of two
+func NewDomain(m uint64) *Domain {
+	n := ecc.NextPowerOfTwo(m)
+	logN := uint64(bits.TrailingZeros64(n))
+	if logN > MaxLogCardinality {
+		panic(fmt.Sprintf("m31 circle domains are limited to 2^%d elements", MaxLogCardinality))
+	}
+
+	domain := &Domain{Cardinality: n}
+	domain.CardinalityInv.SetUint64(n).Inverse(&domain.CardinalityInv)
+
+	// q has order 2N
+	g := CircleGenerator()
+	domain.Offset.ScalarMul(&g, 1<<(LogOrderCircleGroup-1-logN))
+	domain.Generator.Double(&domain.Offset)
+
+	domain.preComputeTwiddles()
+
+	return domain
+}
+
+// Point returns the i-th point q⋅gⁱ of the domain
+func (d *Domain) Point(i uint64) CirclePoint {
+	var p CirclePoint
+	p.ScalarMul(&d.Generator, i%d.Cardinality)
+	return *p.Add(&p, &d.Offset)
+}
+
+func (d *Domain) preComputeTwiddles() {
+	n := int(d.Cardinality)
+	if n < 2 {
+		return
+	}
+	nbLayers := bits.TrailingZeros64(d.Cardinality)
+	d.Twiddles = make([][]m31.Element, nbLayers)
+	d.TwiddlesInv = make([][]m31.Element, nbLayers)
+
+	// first layer: y-coordinates of the first half of the domain
+	d.Twiddles[0] = make([]m31.Element, n/2)
+	var x []m31.Element
+	if n >= 4 {
+		x = make([]m31.Element, n/4)
+	}
+	p := d.Offset
+	for i := 0; i < n/2; i++ {
+		d.Twiddles[0][i] = p.Y
+		if i < len(x) {
+			x[i] = p.X
+		}
+		p.Add(&p, &d.Generator)
+	}
+
+	// next layers: iterates of the x-coordinates under π(x) = 2x² - 1
+	var one m31.Element
+	one.SetOne()
+	for l := 1; l < nbLayers; l++ {
+		d.Twiddles[l] = make([]m31.Element, n>>(l+1))
+		copy(d.Twiddles[l], x)
+		for i := 0; i < len(d.Twiddles[l])/2; i++ {
+			x[i].Square(&x[i]).Double(&x[i]).Sub(&x[i], &one)
+		}
+		x = x[:len(d.Twiddles[l])/2]
+	}
+
+	for l := range d.Twiddles {
+		d.TwiddlesInv[l] = m31.BatchInvert(d.Twiddles[l])
+	}
+}
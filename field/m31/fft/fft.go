@@ -0,0 +1,136 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/field/m31"
+)
+
+// FFT evaluates the polynomial with coordinates a (in the basis described in
+// the package documentation) on the domain. The result is in place, in the
+// natural order of the domain: a[i] = P(d.Point(i)).
+func (d *Domain) FFT(a []m31.Element) {
+	if uint64(len(a)) != d.Cardinality {
+		panic("invalid input size")
+	}
+	n := len(a)
+	bitReverse(a)
+
+	var t m31.Element
+	for l := len(d.Twiddles) - 1; l >= 0; l-- {
+		m := n >> l
+		h := m / 2
+		tw := d.Twiddles[l]
+		for s := 0; s < n; s += m {
+			b := a[s : s+m]
+			reverse(b[h:])
+			for i := 0; i < h; i++ {
+				// P(x) = P₀(x) + t⋅P₁(x) and P(x̄) = P₀(x) - t⋅P₁(x)
+				t.Mul(&b[m-1-i], &tw[i])
+				b[m-1-i].Sub(&b[i], &t)
+				b[i].Add(&b[i], &t)
+			}
+		}
+	}
+}
+
+// FFTInverse interpolates the evaluations a, given in the natural order of the
+// domain, and sets a to the coordinates of the polynomial in the basis described
+// in the package documentation.
+func (d *Domain) FFTInverse(a []m31.Element) {
+	if uint64(len(a)) != d.Cardinality {
+		panic("invalid input size")
+	}
+	n := len(a)
+
+	var u m31.Element
+	for l := range d.TwiddlesInv {
+		m := n >> l
+		h := m / 2
+		tw := d.TwiddlesInv[l]
+		for s := 0; s < n; s += m {
+			b := a[s : s+m]
+			for i := 0; i < h; i++ {
+				// 2P₀(x) = P(x) + P(x̄) and 2t⋅P₁(x) = P(x) - P(x̄)
+				u = b[i]
+				b[i].Add(&u, &b[m-1-i])
+				b[m-1-i].Sub(&u, &b[m-1-i]).Mul(&b[m-1-i], &tw[i])
+			}
+			reverse(b[h:])
+		}
+	}
+
+	for i := range a {
+		a[i].Mul(&a[i], &d.CardinalityInv)
+	}
+	bitReverse(a)
+}
+
+// Evaluate returns the evaluation at p of the polynomial with coordinates
+// coeffs (in the basis described in the package documentation).
+// len(coeffs) must be a power of two.
+func Evaluate(coeffs []m31.Element, p CirclePoint) m31.Element {
+	n := len(coeffs)
+	if n == 0 {
+		return m31.Element{}
+	}
+	if n&(n-1) != 0 {
+		panic("the number of coefficients must be a power of two")
+	}
+
+	v := make([]m31.Element, n)
+	copy(v, coeffs)
+
+	// fold the lowest bit of the index with y, then the next ones with x, π(x), ...
+	var one, t m31.Element
+	one.SetOne()
+	f := p.Y
+	x := p.X
+	for m := n; m > 1; m /= 2 {
+		for i := 0; i < m/2; i++ {
+			t.Mul(&v[2*i+1], &f)
+			v[i].Add(&v[2*i], &t)
+		}
+		f = x
+		x.Square(&x).Double(&x).Sub(&x, &one)
+	}
+	return v[0]
+}
+
+// bitReverse applies the bit-reversal permutation to a.
+// len(a) must be a power of 2.
+func bitReverse(a []m31.Element) {
+	n := uint64(len(a))
+	if n < 2 {
+		return
+	}
+	nn := uint64(64 - bits.TrailingZeros64(n))
+
+	for i := uint64(0); i < n; i++ {
+		irev := bits.Reverse64(i) >> nn
+		if irev > i {
+			a[i], a[irev] = a[irev], a[i]
+		}
+	}
+}
+
+// reverse reverses the order of the elements of a
+func reverse(a []m31.Element) {
+	for i, j := 0, len(a)-1; i < j; i, j = i+1, j-1 {
+		a[i], a[j] = a[j], a[i]
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fft
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/field/m31"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircleGroup(t *testing.T) {
+	assert := require.New(t)
+
+	g := CircleGenerator()
+	assert.True(g.IsOnCircle())
+
+	var p, identity CirclePoint
+	identity.SetIdentity()
+
+	// g has order exactly 2³¹
+	p.ScalarMul(&g, 1<<(LogOrderCircleGroup-1))
+	assert.False(p.Equal(&identity))
+	p.Double(&p)
+	assert.True(p.Equal(&identity))
+
+	// the conjugate is the inverse
+	p.Conjugate(&g).Add(&p, &g)
+	assert.True(p.Equal(&identity))
+}
+
+func TestFFT(t *testing.T) {
+	assert := require.New(t)
+
+	for _, n := range []uint64{1, 2, 4, 8, 64, 1 << 10} {
+		domain := NewDomain(n)
+		assert.Equal(n, domain.Cardinality)
+
+		coeffs := randomVector(int(n))
+		evals := make([]m31.Element, n)
+		copy(evals, coeffs)
+		domain.FFT(evals)
+
+		for i := uint64(0); i < n; i++ {
+			p := domain.Point(i)
+			assert.True(p.IsOnCircle())
+			expected := Evaluate(coeffs, p)
+			assert.True(expected.Equal(&evals[i]), "FFT mismatch at index %d (size %d)", i, n)
+		}
+
+		domain.FFTInverse(evals)
+		assert.Equal(coeffs, evals, "FFTInverse(FFT(a)) != a (size %d)", n)
+	}
+}
+
+func TestDomainPoints(t *testing.T) {
+	assert := require.New(t)
+
+	const n = 64
+	domain := NewDomain(n)
+
+	// the domain is stable under conjugation and antipode
+	points := make(map[CirclePoint]bool, n)
+	for i := uint64(0); i < n; i++ {
+		points[domain.Point(i)] = true
+	}
+	assert.Equal(n, len(points))
+	for p := range points {
+		var c CirclePoint
+		c.Conjugate(&p)
+		assert.True(points[c])
+		c.X.Neg(&c.X)
+		assert.True(points[c])
+	}
+}
+
+func TestLowDegreeExtension(t *testing.T) {
+	assert := require.New(t)
+
+	// a polynomial interpolated on a small domain can be evaluated on a larger one
+	const n = 16
+	small, large := NewDomain(n), NewDomain(4*n)
+
+	evals := randomVector(n)
+	coeffs := make([]m31.Element, n)
+	copy(coeffs, evals)
+	small.FFTInverse(coeffs)
+
+	for i := uint64(0); i < n; i++ {
+		e := Evaluate(coeffs, small.Point(i))
+		assert.True(e.Equal(&evals[i]))
+	}
+
+	// the first n elements of the basis of the large domain are the basis of
+	// the small domain, so the zero-padded coordinates describe the same function
+	extended := make([]m31.Element, 4*n)
+	copy(extended, coeffs)
+	large.FFT(extended)
+	for i := uint64(0); i < 4*n; i++ {
+		e := Evaluate(coeffs, large.Point(i))
+		assert.True(e.Equal(&extended[i]))
+	}
+}
+
+func BenchmarkFFT(b *testing.B) {
+	const n = 1 << 20
+	domain := NewDomain(n)
+	a := randomVector(n)
+
+	b.Run("FFT", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			domain.FFT(a)
+		}
+	})
+	b.Run("FFTInverse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			domain.FFTInverse(a)
+		}
+	})
+}
+
+func randomVector(n int) []m31.Element {
+	v := make([]m31.Element, n)
+	for i := range v {
+		v[i].SetRandom()
+	}
+	return v
+}
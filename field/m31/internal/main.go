@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/field/generator"
+	"github.com/consensys/gnark-crypto/field/generator/config"
+)
+
+//go:generate go run main.go
+func main() {
+	const modulus = "0x7FFFFFFF"
+	m31, err := config.NewFieldConfig("m31", "Element", modulus, true)
+	if err != nil {
+		panic(err)
+	}
+	if err := generator.GenerateFF(m31, "../"); err != nil {
+		panic(err)
+	}
+	fmt.Println("successfully generated m31 field")
+}
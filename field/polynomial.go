@@ -0,0 +1,25 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package field
+
+// PolynomialEval evaluates the polynomial with coefficients coeffs (in the
+// canonical basis, constant term first) at x, using Horner's method.
+func PolynomialEval[T any, PT Element[T]](coeffs []T, x *T) (res T) {
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		PT(&res).Mul(&res, x)
+		PT(&res).Add(&res, &coeffs[i])
+	}
+	return
+}
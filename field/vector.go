@@ -0,0 +1,81 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package field
+
+// VectorAdd sets res[i] = a[i] + b[i].
+// It panics if the vectors don't have the same length.
+func VectorAdd[T any, PT Element[T]](res, a, b []T) {
+	if len(a) != len(b) || len(a) != len(res) {
+		panic("vector.Add: vectors don't have the same length")
+	}
+	for i := range res {
+		PT(&res[i]).Add(&a[i], &b[i])
+	}
+}
+
+// VectorSub sets res[i] = a[i] - b[i].
+// It panics if the vectors don't have the same length.
+func VectorSub[T any, PT Element[T]](res, a, b []T) {
+	if len(a) != len(b) || len(a) != len(res) {
+		panic("vector.Sub: vectors don't have the same length")
+	}
+	for i := range res {
+		PT(&res[i]).Sub(&a[i], &b[i])
+	}
+}
+
+// VectorMul sets res[i] = a[i] * b[i].
+// It panics if the vectors don't have the same length.
+func VectorMul[T any, PT Element[T]](res, a, b []T) {
+	if len(a) != len(b) || len(a) != len(res) {
+		panic("vector.Mul: vectors don't have the same length")
+	}
+	for i := range res {
+		PT(&res[i]).Mul(&a[i], &b[i])
+	}
+}
+
+// VectorScalarMul sets res[i] = a[i] * c.
+// It panics if the vectors don't have the same length.
+func VectorScalarMul[T any, PT Element[T]](res, a []T, c *T) {
+	if len(a) != len(res) {
+		panic("vector.ScalarMul: vectors don't have the same length")
+	}
+	for i := range res {
+		PT(&res[i]).Mul(&a[i], c)
+	}
+}
+
+// VectorSum returns the sum of the elements of a.
+func VectorSum[T any, PT Element[T]](a []T) (res T) {
+	for i := range a {
+		PT(&res).Add(&res, &a[i])
+	}
+	return
+}
+
+// VectorInnerProduct returns the sum of a[i] * b[i].
+// It panics if the vectors don't have the same length.
+func VectorInnerProduct[T any, PT Element[T]](a, b []T) (res T) {
+	if len(a) != len(b) {
+		panic("vector.InnerProduct: vectors don't have the same length")
+	}
+	var tmp T
+	for i := range a {
+		PT(&tmp).Mul(&a[i], &b[i])
+		PT(&res).Add(&res, &tmp)
+	}
+	return
+}
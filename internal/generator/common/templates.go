@@ -0,0 +1,39 @@
+// Package common holds helpers shared by the code generators.
+package common
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ExtractTemplates copies the files of fsys into a new temporary directory and returns its path.
+//
+// bavard parses templates from disk; generators embed their templates and extract them
+// so they can run from any working directory (in particular from the goff binary).
+// The caller is responsible for removing the directory.
+func ExtractTemplates(fsys fs.FS) (string, error) {
+	dir, err := os.MkdirTemp("", "gnark-crypto-templates")
+	if err != nil {
+		return "", err
+	}
+	err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dir, filepath.FromSlash(path))
+		if d.IsDir() {
+			return os.MkdirAll(dst, 0700)
+		}
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dst, content, 0600)
+	})
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
@@ -6,6 +6,11 @@ var BLS24_315 = Curve{
 	EnumID:       "BLS24_315",
 	FrModulus:    "11502027791375260645628074404575422495959608200132055716665986169834464870401",
 	FpModulus:    "39705142709513438335025689890408969744933502416914749335064285505637884093126342347073617133569",
+	FFT: FFT{
+		GeneratorFullMultiplicativeGroup: 7,
+		GeneratorMaxTwoAdicSubgroup:      "1792993287828780812362846131493071959406149719416102105453370749552622525216",
+		LogTwoOrderMaxTwoAdicSubgroup:    22,
+	},
 	G1: Point{
 		CoordType:        "fp.Element",
 		CoordExtDegree:   1,
@@ -6,6 +6,11 @@ var BN254 = Curve{
 	EnumID:       "BN254",
 	FrModulus:    "21888242871839275222246405745257275088548364400416034343698204186575808495617",
 	FpModulus:    "21888242871839275222246405745257275088696311157297823662689037894645226208583",
+	FFT: FFT{
+		GeneratorFullMultiplicativeGroup: 5,
+		GeneratorMaxTwoAdicSubgroup:      "19103219067921713944291392827692070036145651957329286315305642004821462161904",
+		LogTwoOrderMaxTwoAdicSubgroup:    28,
+	},
 	G1: Point{
 		CoordType:        "fp.Element",
 		CoordExtDegree:   1,
@@ -6,6 +6,11 @@ var BW6_756 = Curve{
 	EnumID:       "BW6_756",
 	FrModulus:    "605248206075306171733248481581800960739847691770924913753520744034740935903401304776283802348837311170974282940417",
 	FpModulus:    "366325390957376286590726555727219947825377821289246188278797409783441745356050456327989347160777465284190855125642086860525706497928518803244008749360363712553766506755227344593404398783886857865261088226271336335268413437902849",
+	FFT: FFT{
+		GeneratorFullMultiplicativeGroup: 5,
+		GeneratorMaxTwoAdicSubgroup:      "199251335866470442271346949249090720992237796757894062992204115206570647302191425225605716521843542790404563904580",
+		LogTwoOrderMaxTwoAdicSubgroup:    41,
+	},
 	G1: Point{
 		CoordType:        "fp.Element",
 		CoordExtDegree:   1,
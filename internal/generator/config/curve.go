@@ -20,6 +20,8 @@ type Curve struct {
 	FpUnusedBits int
 
 	FpInfo, FrInfo Field
+	FFT            FFT    // fft domains over fr
+	ECFFT          *ECFFT // elliptic curve fft domains over fr, for curves whose fr has a small 2-adicity
 	G1             Point
 	G2             Point
 
@@ -0,0 +1,10 @@
+package config
+
+// ECFFT describes the curve whose isogeny chain gives the elliptic curve fft domains over a field
+// with a small 2-adicity (see the ecfft package); values are in base 10
+type ECFFT struct {
+	A, B     string // E: y² = x³ + A⋅x + B
+	GX, GY   string // point of order 2^LogOrder on E
+	RX, RY   string // point such that the x(R + [i]G) are distinct, that is 2R ∉ <G>
+	LogOrder uint64 // log₂ of the order of G, and of the largest domain
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// FFT describes the parameters of the fft domains over a field
+type FFT struct {
+	GeneratorFullMultiplicativeGroup uint64 // generator of the full multiplicative group of the field
+	GeneratorMaxTwoAdicSubgroup      string // generator of the largest 2-adic subgroup of the field, in base 10
+	LogTwoOrderMaxTwoAdicSubgroup    uint64 // log₂ of the order of the largest 2-adic subgroup of the field
+}
+
+// GoldilocksFFT are the fft parameters of the goldilocks field p = 2⁶⁴ - 2³² + 1
+var GoldilocksFFT = FFT{
+	GeneratorFullMultiplicativeGroup: 7,
+	GeneratorMaxTwoAdicSubgroup:      "1753635133440165772",
+	LogTwoOrderMaxTwoAdicSubgroup:    32,
+}
+
+// BabyBearFFT are the fft parameters of the babybear field p = 15⋅2²⁷ + 1
+var BabyBearFFT = FFT{
+	GeneratorFullMultiplicativeGroup: 31,
+	GeneratorMaxTwoAdicSubgroup:      "440564289",
+	LogTwoOrderMaxTwoAdicSubgroup:    27,
+}
+
+// NewFFT computes the fft parameters of the prime field of order q.
+//
+// It returns an error if the largest 2-adic subgroup of the field has order less than 2^minLogTwoOrder.
+// GeneratorFullMultiplicativeGroup is the smallest generator of the multiplicative group when the
+// factorization of q-1 is found by trial division; otherwise it falls back to the smallest quadratic
+// non-residue outside the 2-adic subgroup, which is all the domains need for their coset tables.
+func NewFFT(q *big.Int, minLogTwoOrder uint64) (FFT, error) {
+	var qMinusOne big.Int
+	qMinusOne.Sub(q, big.NewInt(1))
+
+	s := uint64(qMinusOne.TrailingZeroBits())
+	if s < minLogTwoOrder {
+		return FFT{}, fmt.Errorf("the largest 2-adic subgroup of the field has order 2^%d, need at least 2^%d", s, minLogTwoOrder)
+	}
+	if s > 63 {
+		return FFT{}, fmt.Errorf("2-adicity %d is not supported", s)
+	}
+	var m big.Int // odd part of q-1
+	m.Rsh(&qMinusOne, uint(s))
+
+	primeFactors, ok := trialDivision(&qMinusOne)
+
+	var gen, e big.Int
+	one := big.NewInt(1)
+	for g := uint64(2); g < 1<<16; g++ {
+		gen.SetUint64(g)
+		if ok {
+			// g generates F_q* iff g^((q-1)/f) ≠ 1 for all prime factors f of q-1
+			isGenerator := true
+			for _, f := range primeFactors {
+				e.Div(&qMinusOne, f)
+				if e.Exp(&gen, &e, q).Cmp(one) == 0 {
+					isGenerator = false
+					break
+				}
+			}
+			if !isGenerator {
+				continue
+			}
+		} else {
+			// g must be a non-residue with g^(2^s) ≠ 1
+			e.Rsh(&qMinusOne, 1)
+			if e.Exp(&gen, &e, q).Cmp(&qMinusOne) != 0 {
+				continue
+			}
+			e.Lsh(one, uint(s))
+			if e.Exp(&gen, &e, q).Cmp(one) == 0 {
+				continue
+			}
+		}
+
+		// g being a non-residue, g^m has order exactly 2^s
+		var root big.Int
+		root.Exp(&gen, &m, q)
+		return FFT{
+			GeneratorFullMultiplicativeGroup: g,
+			GeneratorMaxTwoAdicSubgroup:      root.String(),
+			LogTwoOrderMaxTwoAdicSubgroup:    s,
+		}, nil
+	}
+	return FFT{}, errors.New("could not find a small generator of the multiplicative group")
+}
+
+// trialDivision returns the distinct prime factors of n if n factors as a product of
+// primes smaller than 2²⁰ and at most one larger (probable) prime.
+func trialDivision(n *big.Int) (factors []*big.Int, ok bool) {
+	var r, d, mod big.Int
+	r.Set(n)
+	for p := int64(2); p < 1<<20; p++ {
+		d.SetInt64(p)
+		if mod.Mod(&r, &d).Sign() != 0 {
+			continue
+		}
+		factors = append(factors, big.NewInt(p))
+		for mod.Mod(&r, &d).Sign() == 0 {
+			r.Div(&r, &d)
+		}
+		if r.IsInt64() && r.Int64() == 1 {
+			return factors, true
+		}
+	}
+	if !r.ProbablyPrime(20) {
+		return nil, false
+	}
+	return append(factors, new(big.Int).Set(&r)), true
+}
@@ -14,6 +14,15 @@ var SECP256K1 = Curve{
 		CofactorCleaning: false,
 		CRange:           []int{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
 	},
+	ECFFT: &ECFFT{
+		A:        "-438091",
+		B:        "-69210570",
+		GX:       "46160642424076801106189732408772457215595398798804945669252549688070340888558",
+		GY:       "105614671346441204461791534487009064876217027658662847176472296536402775519639",
+		RX:       "3",
+		RY:       "40359134825525931410878887190574481950564715592767796558037517119520918808578",
+		LogOrder: 18,
+	},
 	HashE1: &HashSuiteSvdw{
 		z:  []string{"1"},
 		c1: []string{"8"},
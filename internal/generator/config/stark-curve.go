@@ -14,6 +14,15 @@ var STARK_CURVE = Curve{
 		CofactorCleaning: false,
 		CRange:           defaultCRange(),
 	},
+	ECFFT: &ECFFT{
+		A:        "-504409",
+		B:        "-28566120",
+		GX:       "590492798051014738102773717335617783557349427661973329925334558754687212193",
+		GY:       "946609825965767552328258826578202169180127251330500897070291634059096186978",
+		RX:       "1",
+		RY:       "1616183784817539735357597249539139505074986713942868147850564249967011154512",
+		LogOrder: 18,
+	},
 	HashE1: &HashSuiteSvdw{
 		z:  []string{"1"},
 		c1: []string{"3141592653589793238462643383279502884197169399375105820974944592307816406667"},
@@ -0,0 +1,37 @@
+package ecfft
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/common"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+//go:embed template
+var templates embed.FS
+
+// Config describes the field over which the ecfft package is generated
+type Config struct {
+	config.FieldDependency
+	ECFFT config.ECFFT
+}
+
+func Generate(conf Config, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "domain.go"), Templates: []string{"domain.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecfft.go"), Templates: []string{"ecfft.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecfft_test.go"), Templates: []string{"tests/ecfft.go.tmpl"}},
+	}
+	templateDir, err := common.ExtractTemplates(templates)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(templateDir)
+
+	return bgen.Generate(conf, "ecfft", filepath.Join(templateDir, "template"), entries...)
+}
@@ -0,0 +1,29 @@
+package extensions
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// Config describes the extensions E2 = F[u]/(u²-β) and E3 = F[v]/(v³-β)
+// over the field F described by FieldDependency
+type Config struct {
+	config.FieldDependency
+	NonResidue uint64 // β, must be both a quadratic and a cubic non-residue in F
+}
+
+func Generate(conf Config, baseDir string, bgen *bavard.BatchGenerator) error {
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "parameters.go"), Templates: []string{"parameters.go.tmpl"}},
+		{File: filepath.Join(baseDir, "e2.go"), Templates: []string{"e2.go.tmpl"}},
+		{File: filepath.Join(baseDir, "e3.go"), Templates: []string{"e3.go.tmpl"}},
+		{File: filepath.Join(baseDir, "generators_test.go"), Templates: []string{"tests/generators.go.tmpl"}},
+		{File: filepath.Join(baseDir, "e2_test.go"), Templates: []string{"tests/e2.go.tmpl"}},
+		{File: filepath.Join(baseDir, "e3_test.go"), Templates: []string{"tests/e3.go.tmpl"}},
+	}
+
+	return bgen.Generate(conf, "extensions", "./extensions/template/", entries...)
+}
@@ -1,21 +1,54 @@
 package fft
 
 import (
+	"embed"
+	"os"
 	"path/filepath"
 
 	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/common"
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 )
 
-func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+//go:embed template
+var templates embed.FS
+
+// Config describes the field over which the fft package is generated
+type Config struct {
+	config.FieldDependency
+	FFT config.FFT
+
+	// CurvePackagePath and CurvePackage, if set, name the package of the curve whose G1 points
+	// FFTG1 and FFTInverseG1 transform
+	CurvePackagePath string
+	CurvePackage     string
+}
+
+func Generate(conf Config, baseDir string, bgen *bavard.BatchGenerator) error {
 
-	conf.Package = "fft"
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
-		{File: filepath.Join(baseDir, "domain_test.go"), Templates: []string{"tests/domain.go.tmpl", "imports.go.tmpl"}},
-		{File: filepath.Join(baseDir, "domain.go"), Templates: []string{"domain.go.tmpl", "imports.go.tmpl"}},
-		{File: filepath.Join(baseDir, "fft_test.go"), Templates: []string{"tests/fft.go.tmpl", "imports.go.tmpl"}},
-		{File: filepath.Join(baseDir, "fft.go"), Templates: []string{"fft.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "domain_test.go"), Templates: []string{"tests/domain.go.tmpl"}},
+		{File: filepath.Join(baseDir, "domain.go"), Templates: []string{"domain.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fft_test.go"), Templates: []string{"tests/fft.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fft.go"), Templates: []string{"fft.go.tmpl"}},
+		{File: filepath.Join(baseDir, "mixed_radix.go"), Templates: []string{"mixed_radix.go.tmpl"}},
+		{File: filepath.Join(baseDir, "coset.go"), Templates: []string{"coset.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fourstep.go"), Templates: []string{"fourstep.go.tmpl"}},
+		{File: filepath.Join(baseDir, "outofcore.go"), Templates: []string{"outofcore.go.tmpl"}},
+		{File: filepath.Join(baseDir, "outofcore_test.go"), Templates: []string{"tests/outofcore.go.tmpl"}},
 	}
-	return bgen.Generate(conf, conf.Package, "./fft/template/", entries...)
+	if conf.CurvePackage != "" {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "fft_g1.go"), Templates: []string{"fft_g1.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "fft_g1_test.go"), Templates: []string{"tests/fft_g1.go.tmpl"}},
+		)
+	}
+	templateDir, err := common.ExtractTemplates(templates)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(templateDir)
+
+	return bgen.Generate(conf, "fft", filepath.Join(templateDir, "template"), entries...)
 }
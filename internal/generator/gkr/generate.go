@@ -12,6 +12,7 @@ type Config struct {
 	RetainTestCaseRawInfo   bool
 	OutsideGkrPackage       bool
 	TestVectorsRelativePath string
+	NoMiMC                  bool // the field package has no mimc sub-package
 }
 
 func Generate(config Config, baseDir string, bgen *bavard.BatchGenerator) error {
@@ -15,6 +15,10 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "kzg.go"), Templates: []string{"kzg.go.tmpl"}},
 		{File: filepath.Join(baseDir, "kzg_test.go"), Templates: []string{"kzg.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "kzg_lagrange.go"), Templates: []string{"kzg_lagrange.go.tmpl"}},
+		{File: filepath.Join(baseDir, "kzg_lagrange_test.go"), Templates: []string{"kzg_lagrange.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "kzg_multipoints.go"), Templates: []string{"kzg_multipoints.go.tmpl"}},
+		{File: filepath.Join(baseDir, "kzg_multipoints_test.go"), Templates: []string{"kzg_multipoints.test.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./kzg/template/", entries...)
@@ -14,8 +14,10 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/mimc"
 	"github.com/consensys/gnark-crypto/internal/generator/ecc"
 	"github.com/consensys/gnark-crypto/internal/generator/ecdsa"
+	"github.com/consensys/gnark-crypto/internal/generator/ecfft"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards/eddsa"
+	"github.com/consensys/gnark-crypto/internal/generator/extensions"
 	"github.com/consensys/gnark-crypto/internal/generator/fft"
 	fri "github.com/consensys/gnark-crypto/internal/generator/fri/template"
 	"github.com/consensys/gnark-crypto/internal/generator/gkr"
@@ -66,6 +68,16 @@ func main() {
 			// generate ecdsa
 			assertNoError(ecdsa.Generate(conf, curveDir, bgen))
 
+			if conf.ECFFT != nil {
+				// generate ecfft on fr, which has no large 2-adic subgroup for the fft
+				frInfo := config.FieldDependency{
+					FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
+					FieldPackageName: "fr",
+					ElementType:      "fr.Element",
+				}
+				assertNoError(ecfft.Generate(ecfft.Config{FieldDependency: frInfo, ECFFT: *conf.ECFFT}, filepath.Join(curveDir, "fr", "ecfft"), bgen))
+			}
+
 			if conf.Equal(config.STARK_CURVE) {
 				return // TODO @yelhousni
 			}
@@ -83,11 +95,22 @@ func main() {
 			// generate pairing tests
 			assertNoError(pairing.Generate(conf, curveDir, bgen))
 
+			frInfo := config.FieldDependency{
+				FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
+				FieldPackageName: "fr",
+				ElementType:      "fr.Element",
+			}
+
 			// generate fri on fr
-			assertNoError(fri.Generate(conf, filepath.Join(curveDir, "fr", "fri"), bgen))
+			assertNoError(fri.Generate(frInfo, filepath.Join(curveDir, "fr", "fri"), bgen))
 
 			// generate fft on fr
-			assertNoError(fft.Generate(conf, filepath.Join(curveDir, "fr", "fft"), bgen))
+			assertNoError(fft.Generate(fft.Config{
+				FieldDependency:  frInfo,
+				FFT:              conf.FFT,
+				CurvePackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name,
+				CurvePackage:     conf.CurvePackage,
+			}, filepath.Join(curveDir, "fr", "fft"), bgen))
 
 			// generate kzg on fr
 			assertNoError(kzg.Generate(conf, filepath.Join(curveDir, "fr", "kzg"), bgen))
@@ -104,33 +127,8 @@ func main() {
 			// generate mimc on fr
 			assertNoError(mimc.Generate(conf, filepath.Join(curveDir, "fr", "mimc"), bgen))
 
-			frInfo := config.FieldDependency{
-				FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
-				FieldPackageName: "fr",
-				ElementType:      "fr.Element",
-			}
-
 			// generate polynomial on fr
-			assertNoError(polynomial.Generate(frInfo, filepath.Join(curveDir, "fr", "polynomial"), true, bgen))
-
-			// generate sumcheck on fr
-			assertNoError(sumcheck.Generate(frInfo, filepath.Join(curveDir, "fr", "sumcheck"), bgen))
-
-			// generate gkr on fr
-			assertNoError(gkr.Generate(gkr.Config{
-				FieldDependency:         frInfo,
-				GenerateTests:           true,
-				TestVectorsRelativePath: "../../../../internal/generator/gkr/test_vectors",
-			}, filepath.Join(curveDir, "fr", "gkr"), bgen))
-
-			// generate test vector utils on fr
-			assertNoError(test_vector_utils.Generate(test_vector_utils.Config{
-				FieldDependency:             frInfo,
-				RandomizeMissingHashEntries: false,
-			}, filepath.Join(curveDir, "fr", "test_vector_utils"), bgen))
-
-			// generate eddsa on companion curves
-			assertNoError(fri.Generate(conf, filepath.Join(curveDir, "fr", "fri"), bgen))
+			assertNoError(polynomial.Generate(polynomial.Config{FieldDependency: frInfo, GenerateTests: true}, filepath.Join(curveDir, "fr", "polynomial"), bgen))
 
 			// generate sumcheck on fr
 			assertNoError(sumcheck.Generate(frInfo, filepath.Join(curveDir, "fr", "sumcheck"), bgen))
@@ -165,12 +163,29 @@ func main() {
 			// generate twisted edwards companion curves
 			assertNoError(edwards.Generate(conf, curveDir, bgen))
 
+			// generate the scalar field of the companion curves, for the secret scalars of eddsa
+			scalarConf, err := field.NewFieldConfig("scalar", "Element", conf.Order, false)
+			assertNoError(err)
+			assertNoError(generator.GenerateFF(scalarConf, filepath.Join(curveDir, "scalar")))
+
 			// generate eddsa on companion curves
 			assertNoError(eddsa.Generate(conf, curveDir, bgen))
 		}(conf)
 
 	}
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assertNoError(generateGoldilocks())
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assertNoError(generateBabyBear())
+	}()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -219,6 +234,73 @@ func main() {
 	wg.Wait()
 }
 
+// generateGoldilocks generates the extensions, fft, fri, polynomial, sumcheck and gkr packages
+// over the goldilocks field p = 2⁶⁴ - 2³² + 1. The field itself is generated
+// by field/goldilocks/internal.
+func generateGoldilocks() error {
+	goldilocksDir := filepath.Join(baseDir, "field", "goldilocks")
+	goldilocksInfo := config.FieldDependency{
+		FieldPackagePath: "github.com/consensys/gnark-crypto/field/goldilocks",
+		FieldPackageName: "goldilocks",
+		ElementType:      "goldilocks.Element",
+	}
+
+	// generate quadratic and cubic extensions of goldilocks
+	// 7 generates the multiplicative group, hence is a quadratic and cubic non-residue
+	if err := extensions.Generate(extensions.Config{FieldDependency: goldilocksInfo, NonResidue: 7}, filepath.Join(goldilocksDir, "extensions"), bgen); err != nil {
+		return err
+	}
+
+	// generate fft on goldilocks
+	if err := fft.Generate(fft.Config{FieldDependency: goldilocksInfo, FFT: config.GoldilocksFFT}, filepath.Join(goldilocksDir, "fft"), bgen); err != nil {
+		return err
+	}
+
+	// generate fri on goldilocks
+	if err := fri.Generate(goldilocksInfo, filepath.Join(goldilocksDir, "fri"), bgen); err != nil {
+		return err
+	}
+
+	// generate polynomial on goldilocks
+	if err := polynomial.Generate(polynomial.Config{FieldDependency: goldilocksInfo, GenerateTests: true}, filepath.Join(goldilocksDir, "polynomial"), bgen); err != nil {
+		return err
+	}
+
+	// generate sumcheck on goldilocks
+	if err := sumcheck.Generate(goldilocksInfo, filepath.Join(goldilocksDir, "sumcheck"), bgen); err != nil {
+		return err
+	}
+
+	// generate gkr on goldilocks
+	if err := gkr.Generate(gkr.Config{
+		FieldDependency:         goldilocksInfo,
+		GenerateTests:           true,
+		TestVectorsRelativePath: "../../../internal/generator/gkr/test_vectors",
+		NoMiMC:                  true,
+	}, filepath.Join(goldilocksDir, "gkr"), bgen); err != nil {
+		return err
+	}
+
+	// generate test vector utils on goldilocks
+	return test_vector_utils.Generate(test_vector_utils.Config{
+		FieldDependency:             goldilocksInfo,
+		RandomizeMissingHashEntries: false,
+	}, filepath.Join(goldilocksDir, "test_vector_utils"), bgen)
+}
+
+// generateBabyBear generates the fft package over the babybear field p = 15⋅2²⁷ + 1.
+// The field itself is generated by field/babybear/internal.
+func generateBabyBear() error {
+	babybearInfo := config.FieldDependency{
+		FieldPackagePath: "github.com/consensys/gnark-crypto/field/babybear",
+		FieldPackageName: "babybear",
+		ElementType:      "babybear.Element",
+	}
+
+	// generate fft on babybear
+	return fft.Generate(fft.Config{FieldDependency: babybearInfo, FFT: config.BabyBearFFT}, filepath.Join(baseDir, "field", "babybear", "fft"), bgen)
+}
+
 func assertNoError(err error) {
 	if err != nil {
 		fmt.Printf("\n%s\n", err.Error())
@@ -1,27 +1,57 @@
 package polynomial
 
 import (
+	"embed"
+	"os"
 	"path/filepath"
 
 	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/common"
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 )
 
-func Generate(conf config.FieldDependency, baseDir string, generateTests bool, bgen *bavard.BatchGenerator) error {
+//go:embed template
+var templates embed.FS
+
+// Config describes the field over which the polynomial package is generated
+type Config struct {
+	config.FieldDependency
+	GenerateTests bool
+	NoFFT         bool // the field package has no fft sub-package, so no FFT based arithmetic (Mul, DivMod, ...)
+}
+
+func Generate(conf Config, baseDir string, bgen *bavard.BatchGenerator) error {
 
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "polynomial.go"), Templates: []string{"polynomial.go.tmpl"}},
 		{File: filepath.Join(baseDir, "multilin.go"), Templates: []string{"multilin.go.tmpl"}},
 		{File: filepath.Join(baseDir, "pool.go"), Templates: []string{"pool.go.tmpl"}},
+		{File: filepath.Join(baseDir, "mle.go"), Templates: []string{"mle.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multilin_conversion.go"), Templates: []string{"multilin_conversion.go.tmpl"}},
+	}
+	if !conf.NoFFT {
+		entries = append(entries, bavard.Entry{File: filepath.Join(baseDir, "arithmetic.go"), Templates: []string{"arithmetic.go.tmpl"}})
 	}
 
-	if generateTests {
+	if conf.GenerateTests {
 		entries = append(entries,
 			bavard.Entry{File: filepath.Join(baseDir, "polynomial_test.go"), Templates: []string{"polynomial.test.go.tmpl"}},
 			bavard.Entry{File: filepath.Join(baseDir, "multilin_test.go"), Templates: []string{"multilin.test.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "pool_test.go"), Templates: []string{"pool.test.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "mle_test.go"), Templates: []string{"mle.test.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "multilin_conversion_test.go"), Templates: []string{"multilin_conversion.test.go.tmpl"}},
 		)
+		if !conf.NoFFT {
+			entries = append(entries, bavard.Entry{File: filepath.Join(baseDir, "arithmetic_test.go"), Templates: []string{"arithmetic.test.go.tmpl"}})
+		}
+	}
+
+	templateDir, err := common.ExtractTemplates(templates)
+	if err != nil {
+		return err
 	}
+	defer os.RemoveAll(templateDir)
 
-	return bgen.Generate(conf, "polynomial", "./polynomial/template/", entries...)
+	return bgen.Generate(conf, "polynomial", filepath.Join(templateDir, "template"), entries...)
 }
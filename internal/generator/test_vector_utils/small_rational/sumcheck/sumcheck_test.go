@@ -24,13 +24,12 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/test_vector_utils/small_rational/test_vector_utils"
 	"github.com/stretchr/testify/assert"
 	"hash"
-	"math/bits"
 	"strings"
 	"testing"
 )
 
 type singleMultilinClaim struct {
-	g polynomial.MultiLin
+	g polynomial.MLE
 }
 
 func (c singleMultilinClaim) ProveFinalEval(r []small_rational.SmallRational) interface{} {
@@ -38,19 +37,17 @@ func (c singleMultilinClaim) ProveFinalEval(r []small_rational.SmallRational) in
 }
 
 func (c singleMultilinClaim) VarsNum() int {
-	return bits.TrailingZeros(uint(len(c.g)))
+	return c.g.NumVars()
 }
 
 func (c singleMultilinClaim) ClaimsNum() int {
 	return 1
 }
 
-func sumForX1One(g polynomial.MultiLin) polynomial.Polynomial {
-	sum := g[len(g)/2]
-	for i := len(g)/2 + 1; i < len(g); i++ {
-		sum.Add(&sum, &g[i])
-	}
-	return []small_rational.SmallRational{sum}
+func sumForX1One(g polynomial.MLE) polynomial.Polynomial {
+	var one small_rational.SmallRational
+	one.SetOne()
+	return []small_rational.SmallRational{g.SumFold(one)}
 }
 
 func (c singleMultilinClaim) Combine(small_rational.SmallRational) polynomial.Polynomial {
@@ -63,7 +60,7 @@ func (c *singleMultilinClaim) Next(r small_rational.SmallRational) polynomial.Po
 }
 
 type singleMultilinLazyClaim struct {
-	g          polynomial.MultiLin
+	g          polynomial.MLE
 	claimedSum small_rational.SmallRational
 }
 
@@ -88,16 +85,24 @@ func (c singleMultilinLazyClaim) ClaimsNum() int {
 }
 
 func (c singleMultilinLazyClaim) VarsNum() int {
-	return bits.TrailingZeros(uint(len(c.g)))
+	return c.g.NumVars()
 }
 
 func testSumcheckSingleClaimMultilin(polyInt []uint64, hashGenerator func() hash.Hash) error {
-	poly := make(polynomial.MultiLin, len(polyInt))
-	for i, n := range polyInt {
-		poly[i].SetUint64(n)
-	}
+	return testSumcheckSingleClaimMLE(func() polynomial.MLE {
+		poly := make(polynomial.MultiLin, len(polyInt))
+		for i, n := range polyInt {
+			poly[i].SetUint64(n)
+		}
+		return &poly
+	}, hashGenerator)
+}
 
-	claim := singleMultilinClaim{g: poly.Clone()}
+// testSumcheckSingleClaimMLE proves and verifies the sum of the multilinear polynomial returned by newPoly,
+// which is called once for the prover and once for the verifier
+func testSumcheckSingleClaimMLE(newPoly func() polynomial.MLE, hashGenerator func() hash.Hash) error {
+	poly := newPoly()
+	claim := singleMultilinClaim{g: newPoly()}
 
 	proof, err := Prove(&claim, fiatshamir.WithHash(hashGenerator()))
 	if err != nil {
@@ -159,3 +164,26 @@ func TestSumcheckDeterministicHashSingleClaimMultilin(t *testing.T) {
 		}
 	}
 }
+
+func TestSumcheckStructuredMultilin(t *testing.T) {
+	const nbVars = 4
+	q := make([]small_rational.SmallRational, nbVars)
+	values := make([]small_rational.SmallRational, 3)
+	for i := range q {
+		q[i].SetUint64(uint64(3*i + 2))
+	}
+	for i := range values {
+		values[i].SetUint64(uint64(i + 5))
+	}
+
+	polys := map[string]func() polynomial.MLE{
+		"sparse":   func() polynomial.MLE { return polynomial.NewSparseMultiLin(nbVars, []int{2, 9, 15}, values) },
+		"eq":       func() polynomial.MLE { return polynomial.NewEqMultiLin(q) },
+		"identity": func() polynomial.MLE { return polynomial.NewIdentityMultiLin(nbVars) },
+		"lagrange": func() polynomial.MLE { return polynomial.NewLagrangeMultiLin(nbVars, 6) },
+	}
+
+	for name, newPoly := range polys {
+		assert.NoError(t, testSumcheckSingleClaimMLE(newPoly, test_vector_utils.NewMessageCounterGenerator(2, 1)), name)
+	}
+}